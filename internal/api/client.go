@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"time"
 
 	"golang.org/x/oauth2"
 
@@ -26,6 +27,15 @@ type Client struct {
 	baseURL     string
 	httpClient  *http.Client
 	tokenSource oauth2.TokenSource
+	collections CollectionStore
+	timeout     time.Duration
+}
+
+// CollectionStore persists the collections tree between invocations.
+type CollectionStore interface {
+	Load() ([]Collection, bool)
+	Save(collections []Collection) error
+	Invalidate() error
 }
 
 // NewClient creates a new API client with the given token source.
@@ -49,6 +59,17 @@ func NewClientWithBaseURL(ts oauth2.TokenSource, baseURL string) *Client {
 	return client
 }
 
+// SetCollectionStore enables caching of the collections tree.
+func (c *Client) SetCollectionStore(store CollectionStore) {
+	c.collections = store
+}
+
+// SetRequestTimeout bounds each API request, including its retries; zero
+// leaves requests bounded only by their context.
+func (c *Client) SetRequestTimeout(d time.Duration) {
+	c.timeout = d
+}
+
 // Token returns the current OAuth token.
 func (c *Client) Token(ctx context.Context) (*oauth2.Token, error) {
 	_ = ctx // context available for future use
@@ -111,6 +132,13 @@ func NewClientFromAuth() (*Client, error) {
 func (c *Client) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
 	reqURL := c.baseURL + path
 
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
 	for attempt := 0; attempt < 2; attempt++ {
 		var bodyReader io.Reader
 		if body != nil {
@@ -70,6 +70,48 @@ func (c *Client) ListAllCollections(ctx context.Context) ([]Collection, error) {
 	return append(root, children...), nil
 }
 
+// Collections returns all collections, served from the collection store when
+// it holds a fresh copy.
+func (c *Client) Collections(ctx context.Context) ([]Collection, error) {
+	collections, _, err := c.loadCollections(ctx)
+
+	return collections, err
+}
+
+// loadCollections is like Collections but also reports whether the result
+// came from the collection store.
+func (c *Client) loadCollections(ctx context.Context) ([]Collection, bool, error) {
+	if c.collections != nil {
+		if cached, ok := c.collections.Load(); ok {
+			return cached, true, nil
+		}
+	}
+
+	collections, err := c.RefreshCollections(ctx)
+
+	return collections, false, err
+}
+
+// RefreshCollections fetches all collections and updates the collection store.
+func (c *Client) RefreshCollections(ctx context.Context) ([]Collection, error) {
+	collections, err := c.ListAllCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.collections != nil {
+		_ = c.collections.Save(collections) // cache is best effort
+	}
+
+	return collections, nil
+}
+
+func (c *Client) invalidateCollections() {
+	if c.collections != nil {
+		_ = c.collections.Invalidate()
+	}
+}
+
 // GetCollection fetches a single collection by ID.
 func (c *Client) GetCollection(ctx context.Context, id int) (*Collection, error) {
 	var resp CollectionResponse
@@ -87,6 +129,8 @@ func (c *Client) CreateCollection(ctx context.Context, req *CreateCollectionRequ
 		return nil, err
 	}
 
+	c.invalidateCollections()
+
 	return &resp.Item, nil
 }
 
@@ -97,10 +141,18 @@ func (c *Client) UpdateCollection(ctx context.Context, id int, req *UpdateCollec
 		return nil, err
 	}
 
+	c.invalidateCollections()
+
 	return &resp.Item, nil
 }
 
 // DeleteCollection deletes a collection.
 func (c *Client) DeleteCollection(ctx context.Context, id int) error {
-	return c.Delete(ctx, fmt.Sprintf("/collection/%d", id))
+	if err := c.Delete(ctx, fmt.Sprintf("/collection/%d", id)); err != nil {
+		return err
+	}
+
+	c.invalidateCollections()
+
+	return nil
 }
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"strings"
+)
+
+// CollectionPathSeparator separates segments in a collection path.
+const CollectionPathSeparator = "/"
+
+// SplitCollectionPath splits a slash-separated collection path into segments.
+// A backslash escapes the next character, so `AC\/DC` is a single segment
+// named "AC/DC". A leading slash anchors the path at the root collection level.
+func SplitCollectionPath(path string) (segments []string, anchored bool) {
+	path = strings.TrimSpace(path)
+	anchored = strings.HasPrefix(path, CollectionPathSeparator)
+
+	var cur strings.Builder
+
+	escaped := false
+
+	for _, r := range path {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case string(r) == CollectionPathSeparator:
+			if s := strings.TrimSpace(cur.String()); s != "" {
+				segments = append(segments, s)
+			}
+
+			cur.Reset()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+
+	if escaped {
+		cur.WriteRune('\\')
+	}
+
+	if s := strings.TrimSpace(cur.String()); s != "" {
+		segments = append(segments, s)
+	}
+
+	return segments, anchored
+}
+
+// EscapeCollectionTitle escapes slashes and backslashes in a collection title
+// so it can be used as a single path segment.
+func EscapeCollectionTitle(title string) string {
+	title = strings.ReplaceAll(title, `\`, `\\`)
+
+	return strings.ReplaceAll(title, CollectionPathSeparator, `\`+CollectionPathSeparator)
+}
+
+// CollectionPaths returns the full slash-separated path for every collection,
+// keyed by collection ID. Titles containing slashes are escaped.
+func CollectionPaths(collections []Collection) map[int]string {
+	byID := make(map[int]*Collection, len(collections))
+	for i := range collections {
+		byID[collections[i].ID] = &collections[i]
+	}
+
+	paths := make(map[int]string, len(collections))
+
+	for i := range collections {
+		col := &collections[i]
+
+		segments := []string{EscapeCollectionTitle(col.Title)}
+		seen := map[int]bool{col.ID: true}
+
+		for parentID := col.ParentID(); parentID != 0 && !seen[parentID]; {
+			parent, ok := byID[parentID]
+			if !ok {
+				break
+			}
+
+			seen[parentID] = true
+			segments = append([]string{EscapeCollectionTitle(parent.Title)}, segments...)
+			parentID = parent.ParentID()
+		}
+
+		paths[col.ID] = strings.Join(segments, CollectionPathSeparator)
+	}
+
+	return paths
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 const (
@@ -61,16 +62,56 @@ func (e *AuthError) Unwrap() error {
 }
 
 type NotFoundError struct {
-	Resource string
-	ID       string
+	Resource    string
+	ID          string
+	Suggestions []string
 }
 
 func (e *NotFoundError) Error() string {
+	msg := fmt.Sprintf("%s not found", e.Resource)
 	if e.ID != "" {
-		return fmt.Sprintf("%s '%s' not found", e.Resource, e.ID)
+		msg = fmt.Sprintf("%s '%s' not found", e.Resource, e.ID)
 	}
 
-	return fmt.Sprintf("%s not found", e.Resource)
+	switch len(e.Suggestions) {
+	case 0:
+		return msg
+	case 1:
+		return fmt.Sprintf("%s; did you mean '%s'?", msg, e.Suggestions[0])
+	default:
+		return fmt.Sprintf("%s; did you mean one of '%s'?", msg, strings.Join(e.Suggestions, "', '"))
+	}
+}
+
+// CollectionCandidate is a possible match for an ambiguous collection name.
+type CollectionCandidate struct {
+	ID   int
+	Path string
+}
+
+// AmbiguousCollectionError is returned when a collection name or path matches
+// more than one collection.
+type AmbiguousCollectionError struct {
+	Name       string
+	Candidates []CollectionCandidate
+}
+
+func (e *AmbiguousCollectionError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "collection '%s' is ambiguous; candidates:", e.Name)
+
+	for _, c := range e.Candidates {
+		fmt.Fprintf(&b, "\n  %s (ID: %d)", c.Path, c.ID)
+	}
+
+	b.WriteString("\n  Use the full path (e.g. Parent/Child) or the ID")
+
+	return b.String()
+}
+
+func (e *AmbiguousCollectionError) ExitCode() int {
+	return ExitUsage
 }
 
 type RateLimitError struct {
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -16,8 +17,12 @@ const SystemCollectionUnsorted = -1
 // SystemCollectionTrash represents trashed raindrops.
 const SystemCollectionTrash = -99
 
-// ResolveCollection converts a name or ID string to a collection ID.
-// Supports: numeric ID, "all" (0), "unsorted" (-1), "trash" (-99), or name lookup.
+// maxCollectionSuggestions limits the "did you mean" hints on a failed lookup.
+const maxCollectionSuggestions = 3
+
+// ResolveCollection converts a name, path or ID string to a collection ID.
+// Supports: numeric ID, "all" (0), "unsorted" (-1), "trash" (-99), a
+// collection name, or a slash-separated path such as "Work/Projects/Go".
 func (c *Client) ResolveCollection(ctx context.Context, nameOrID string) (int, error) {
 	s := strings.TrimSpace(strings.ToLower(nameOrID))
 
@@ -36,22 +41,168 @@ func (c *Client) ResolveCollection(ctx context.Context, nameOrID string) (int, e
 		return id, nil
 	}
 
-	// Name lookup
-	collections, err := c.ListAllCollections(ctx)
+	// Name or path lookup
+	collections, cached, err := c.loadCollections(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("fetch collections for lookup: %w", err)
 	}
 
-	// Case-insensitive title match
+	id, err := FindCollection(collections, nameOrID)
+	if err == nil || !cached {
+		return id, err
+	}
+
+	// The cached tree may predate a collection created elsewhere; retry once
+	// against fresh data before giving up.
+	if collections, err = c.RefreshCollections(ctx); err != nil {
+		return 0, fmt.Errorf("fetch collections for lookup: %w", err)
+	}
+
+	return FindCollection(collections, nameOrID)
+}
+
+// FindCollection looks up a collection by name or slash-separated path.
+// Matching is case-insensitive. A path matching from the root wins, so the
+// full paths of CollectionPaths always resolve; otherwise it matches any
+// collection whose trailing path segments are equal to it, unless it starts
+// with a slash. Multiple matches yield an *AmbiguousCollectionError; no
+// match yields a *NotFoundError with suggestions based on prefix and fuzzy
+// matching.
+func FindCollection(collections []Collection, ref string) (int, error) {
+	segments, anchored := SplitCollectionPath(ref)
+	if len(segments) == 0 {
+		return 0, &NotFoundError{Resource: "collection", ID: ref}
+	}
+
+	byID := make(map[int]*Collection, len(collections))
+	for i := range collections {
+		byID[collections[i].ID] = &collections[i]
+	}
+
+	var matches, rooted []*Collection
+
+	for i := range collections {
+		if matchCollectionPath(&collections[i], segments, true, byID) {
+			rooted = append(rooted, &collections[i])
+		}
+
+		if matchCollectionPath(&collections[i], segments, anchored, byID) {
+			matches = append(matches, &collections[i])
+		}
+	}
+
+	if len(rooted) > 0 {
+		matches = rooted
+	}
+
+	paths := CollectionPaths(collections)
+
+	switch len(matches) {
+	case 1:
+		return matches[0].ID, nil
+	case 0:
+		return 0, &NotFoundError{
+			Resource:    "collection",
+			ID:          ref,
+			Suggestions: suggestCollections(collections, paths, segments[len(segments)-1]),
+		}
+	}
+
+	candidates := make([]CollectionCandidate, 0, len(matches))
+	for _, m := range matches {
+		candidates = append(candidates, CollectionCandidate{ID: m.ID, Path: paths[m.ID]})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Path < candidates[j].Path
+	})
+
+	return 0, &AmbiguousCollectionError{Name: ref, Candidates: candidates}
+}
+
+func matchCollectionPath(col *Collection, segments []string, anchored bool, byID map[int]*Collection) bool {
+	cur := col
+
+	for i := len(segments) - 1; i >= 0; i-- {
+		if cur == nil || !strings.EqualFold(cur.Title, segments[i]) {
+			return false
+		}
+
+		cur = byID[cur.ParentID()]
+	}
+
+	return !anchored || cur == nil
+}
+
+// suggestCollections ranks collection paths by how closely their title
+// resembles name: prefix matches first, then substring matches, then titles
+// within a small edit distance.
+func suggestCollections(collections []Collection, paths map[int]string, name string) []string {
+	type scored struct {
+		path  string
+		score int
+	}
+
+	needle := strings.ToLower(name)
+	maxDist := max(1, len([]rune(needle))/3)
+
+	var ranked []scored
+
 	for _, col := range collections {
-		if strings.EqualFold(col.Title, nameOrID) {
-			return col.ID, nil
+		title := strings.ToLower(col.Title)
+
+		switch {
+		case strings.HasPrefix(title, needle):
+			ranked = append(ranked, scored{path: paths[col.ID], score: 0})
+		case strings.Contains(title, needle):
+			ranked = append(ranked, scored{path: paths[col.ID], score: 1})
+		default:
+			if d := levenshtein(title, needle); d <= maxDist {
+				ranked = append(ranked, scored{path: paths[col.ID], score: 1 + d})
+			}
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score < ranked[j].score
 		}
+
+		return ranked[i].path < ranked[j].path
+	})
+
+	suggestions := make([]string, 0, min(len(ranked), maxCollectionSuggestions))
+	for i := 0; i < len(ranked) && i < maxCollectionSuggestions; i++ {
+		suggestions = append(suggestions, ranked[i].path)
+	}
+
+	return suggestions
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
 	}
 
-	return 0, &APIError{
-		StatusCode: 404,
-		Message:    "collection not found",
-		Details:    nameOrID,
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, cur = cur, prev
 	}
+
+	return prev[len(rb)]
 }
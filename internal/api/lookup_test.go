@@ -0,0 +1,83 @@
+package api
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindCollection(t *testing.T) {
+	col := func(id, parent int, title string) Collection {
+		c := Collection{ID: id, Title: title}
+		if parent != 0 {
+			c.Parent = &CollectionRef{ID: parent}
+		}
+
+		return c
+	}
+
+	collections := []Collection{
+		col(1, 0, "Work"),
+		col(2, 1, "Archive"),
+		col(3, 0, "Personal"),
+		col(4, 3, "Work"),
+		col(5, 4, "Archive"),
+		col(6, 0, "AC/DC"),
+		col(7, 3, "Archive"),
+	}
+
+	tests := []struct {
+		ref       string
+		want      int
+		ambiguous bool
+	}{
+		{ref: "Personal", want: 3},
+		{ref: "personal/work", want: 4},
+		{ref: "Work", want: 1},
+		{ref: "Work/Archive", want: 2},
+		{ref: "Personal/Work/Archive", want: 5},
+		{ref: "/Work", want: 1},
+		{ref: `AC\/DC`, want: 6},
+		{ref: "Archive", ambiguous: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			id, err := FindCollection(collections, tt.ref)
+
+			var ambiguous *AmbiguousCollectionError
+			if tt.ambiguous {
+				if !errors.As(err, &ambiguous) {
+					t.Fatalf("FindCollection(%q) error = %v, want ambiguous", tt.ref, err)
+				}
+
+				return
+			}
+
+			if err != nil || id != tt.want {
+				t.Errorf("FindCollection(%q) = %d, %v; want %d", tt.ref, id, err, tt.want)
+			}
+		})
+	}
+
+	// Every full path resolves to its collection.
+	for id, path := range CollectionPaths(collections) {
+		if got, err := FindCollection(collections, path); err != nil || got != id {
+			t.Errorf("FindCollection(%q) = %d, %v; want %d", path, got, err, id)
+		}
+	}
+}
+
+func TestFindCollectionNotFound(t *testing.T) {
+	collections := []Collection{{ID: 1, Title: "Reading"}}
+
+	_, err := FindCollection(collections, "Readng")
+
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("error = %v, want *NotFoundError", err)
+	}
+
+	if len(notFound.Suggestions) != 1 || notFound.Suggestions[0] != "Reading" {
+		t.Errorf("suggestions = %v, want [Reading]", notFound.Suggestions)
+	}
+}
@@ -5,9 +5,14 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
-// ListOptions configures list/search requests.
+// MaxPerPage is the largest page size the API accepts.
+const MaxPerPage = 50
+
+// ListOptions configures list/search requests. Page is zero-based.
 type ListOptions struct {
 	Search  string
 	Sort    string
@@ -38,22 +43,65 @@ type CreateRaindropRequest struct {
 	Collection struct {
 		ID int `json:"$id"`
 	} `json:"collection,omitempty"`
-	PleaseParse bool `json:"pleaseParse,omitempty"` //nolint:tagliatelle // API uses camelCase
+	Created     *time.Time `json:"created,omitempty"`
+	PleaseParse bool       `json:"pleaseParse,omitempty"` //nolint:tagliatelle // API uses camelCase
 }
 
-// UpdateRaindropRequest is the payload for updating a raindrop.
+// UpdateRaindropRequest is the payload for updating a raindrop. Nil fields
+// are left unchanged; a pointer to an empty value clears the field.
 type UpdateRaindropRequest struct {
-	Link       string   `json:"link,omitempty"`
-	Title      string   `json:"title,omitempty"`
-	Excerpt    string   `json:"excerpt,omitempty"`
-	Note       string   `json:"note,omitempty"`
-	Tags       []string `json:"tags,omitempty"`
-	Important  *bool    `json:"important,omitempty"`
+	Link       *string   `json:"link,omitempty"`
+	Title      *string   `json:"title,omitempty"`
+	Excerpt    *string   `json:"excerpt,omitempty"`
+	Note       *string   `json:"note,omitempty"`
+	Cover      *string   `json:"cover,omitempty"`
+	Tags       *[]string `json:"tags,omitempty"`
+	Important  *bool     `json:"important,omitempty"`
 	Collection *struct {
 		ID int `json:"$id"`
 	} `json:"collection,omitempty"`
 }
 
+// SetTags replaces the tags; an empty list clears them.
+func (r *UpdateRaindropRequest) SetTags(tags []string) {
+	if tags == nil {
+		tags = []string{}
+	}
+
+	r.Tags = &tags
+}
+
+// SetCollection moves the raindrop to the collection id.
+func (r *UpdateRaindropRequest) SetCollection(id int) {
+	r.Collection = &struct {
+		ID int `json:"$id"`
+	}{ID: id}
+}
+
+// EditTags returns tags with add appended and remove dropped, ignoring case
+// and duplicates.
+func EditTags(tags, add, remove []string) []string {
+	out := make([]string, 0, len(tags)+len(add))
+
+	for _, tag := range append(append([]string{}, tags...), add...) {
+		drop := false
+
+		for _, r := range remove {
+			drop = drop || strings.EqualFold(tag, r)
+		}
+
+		for _, t := range out {
+			drop = drop || strings.EqualFold(tag, t)
+		}
+
+		if !drop {
+			out = append(out, tag)
+		}
+	}
+
+	return out
+}
+
 // ListRaindrops fetches raindrops from a collection.
 // collectionID: 0 = all, -1 = unsorted, -99 = trash
 func (c *Client) ListRaindrops(ctx context.Context, collectionID int, opts ListOptions) (*RaindropsResponse, error) {
@@ -73,7 +121,7 @@ func (c *Client) ListRaindrops(ctx context.Context, collectionID int, opts ListO
 
 	perPage := opts.PerPage
 	if perPage == 0 {
-		perPage = 50
+		perPage = MaxPerPage
 	}
 
 	params.Set("perpage", strconv.Itoa(perPage))
@@ -153,3 +201,25 @@ func (c *Client) CreateRaindropsBulk(ctx context.Context, items []CreateRaindrop
 
 	return resp.Items, nil
 }
+
+// LinkID returns the ID of a raindrop saved with link, or 0 if there is
+// none. The API matches links as it normalizes them.
+func (c *Client) LinkID(ctx context.Context, link string) (int, error) {
+	req := struct {
+		URLs []string `json:"urls"`
+	}{URLs: []string{link}}
+
+	var resp struct {
+		IDs []int `json:"ids"`
+	}
+
+	if err := c.Post(ctx, "/import/url/exists", &req, &resp); err != nil {
+		return 0, err
+	}
+
+	if len(resp.IDs) == 0 {
+		return 0, nil
+	}
+
+	return resp.IDs[0], nil
+}
@@ -2,6 +2,8 @@ package auth
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"sync"
@@ -49,6 +51,19 @@ func (ts *TestTokenSource) Token() (*oauth2.Token, error) {
 	return nil, ErrNotAuthenticated
 }
 
+// AccountKey identifies the account in use without revealing its token: a
+// hash of $RAINDROP_TOKEN, or "keyring" for the stored login, which is
+// replaced only by auth login and logout.
+func AccountKey() string {
+	if envToken := os.Getenv("RAINDROP_TOKEN"); envToken != "" {
+		sum := sha256.Sum256([]byte(envToken))
+
+		return "env-" + hex.EncodeToString(sum[:6])
+	}
+
+	return "keyring"
+}
+
 // RefreshTokenSource uses a refresh token directly (used during login).
 type RefreshTokenSource struct {
 	creds        OAuthCredentials
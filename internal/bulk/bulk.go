@@ -0,0 +1,280 @@
+// Package bulk runs operations over many items in batches, with retries,
+// per-item results and an optional checkpoint for resuming.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Status is the outcome of one item.
+type Status string
+
+const (
+	StatusDone    Status = "done"
+	StatusSkipped Status = "skipped"
+	StatusFailed  Status = "failed"
+)
+
+// Result is the outcome of one item. Key identifies the item (an input
+// line, an ID); ID is the raindrop it produced or touched.
+type Result struct {
+	Key     string `json:"key"`
+	Status  Status `json:"status"`
+	ID      int    `json:"id,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Options configures Run.
+type Options struct {
+	// BatchSize is the number of items passed to the operation at once.
+	BatchSize int
+	// Retries is how often a failed batch is retried before its items are
+	// tried one by one.
+	Retries int
+	// Delay is the wait before the first retry; it doubles after each.
+	Delay time.Duration
+	// Retryable reports whether an error may go away on retry; nil retries
+	// every error.
+	Retryable func(error) bool
+	// Unsure reports whether a failed attempt may have taken effect anyway,
+	// e.g. after a timeout or a dropped connection. Such a batch is neither
+	// retried nor split until Lookup confirms which items weren't applied.
+	// Nil treats every error as a clean failure.
+	Unsure func(error) bool
+	// Lookup returns, for each key, the ID its item already produced or 0,
+	// after an unsure error. It bounds its own requests. Without it, the
+	// items of such a batch fail so they aren't applied twice.
+	Lookup func(ctx context.Context, keys []string) ([]int, error)
+	// Timeout bounds each attempt; zero means no limit.
+	Timeout time.Duration
+	// Checkpoint, when set, skips items it records as done and records
+	// each finished batch.
+	Checkpoint *Checkpoint
+	// Progress is called after each batch with the number of finished
+	// items.
+	Progress func(finished, total int)
+}
+
+// DefaultRetries and DefaultDelay are the usual retry settings. HTTP rate
+// limits and server errors are already retried by the API client; these
+// cover failures that outlast them.
+const (
+	DefaultRetries = 1
+	DefaultDelay   = 2 * time.Second
+)
+
+// Func runs the operation on a batch and returns the resulting raindrop
+// IDs, in batch order.
+type Func[T any] func(ctx context.Context, batch []T) ([]int, error)
+
+// Run applies fn to items in batches. A batch that still fails after the
+// retries is split, so one bad item only fails itself; see Options.Unsure
+// for operations that can't safely be repeated. The results are in
+// item order. The error is set when ctx is cancelled, with the results so
+// far (unprocessed items are left out), or when the checkpoint can't be
+// written.
+func Run[T any](ctx context.Context, items []T, key func(T) string, fn Func[T], opts Options) ([]Result, error) {
+	opts.BatchSize = max(1, opts.BatchSize)
+
+	results := make([]*Result, len(items))
+	pending := make([]int, 0, len(items))
+
+	for i, item := range items {
+		k := key(item)
+
+		if id, ok := opts.Checkpoint.Done(k); ok {
+			results[i] = &Result{Key: k, Status: StatusSkipped, ID: id, Message: "already done"}
+
+			continue
+		}
+
+		pending = append(pending, i)
+	}
+
+	finished := len(items) - len(pending)
+
+	var err error
+
+	for start := 0; start < len(pending) && err == nil; start += opts.BatchSize {
+		batch := pending[start:min(start+opts.BatchSize, len(pending))]
+
+		err = runBatch(ctx, items, batch, key, fn, opts, results)
+
+		if saveErr := opts.Checkpoint.record(results, batch); saveErr != nil && err == nil {
+			err = saveErr
+		}
+
+		finished += len(batch)
+
+		if opts.Progress != nil {
+			opts.Progress(finished, len(items))
+		}
+	}
+
+	out := make([]Result, 0, len(items))
+
+	for _, r := range results {
+		if r != nil {
+			out = append(out, *r)
+		}
+	}
+
+	return out, err
+}
+
+// runBatch fills the results of the items at indexes. The error is only
+// set when ctx is done.
+func runBatch[T any](ctx context.Context, items []T, indexes []int, key func(T) string, fn Func[T], opts Options, results []*Result) error {
+	batch := make([]T, len(indexes))
+	for i, index := range indexes {
+		batch[i] = items[index]
+	}
+
+	ids, err := attempt(ctx, batch, fn, opts)
+	if err == nil {
+		for i, index := range indexes {
+			r := &Result{Key: key(items[index]), Status: StatusDone}
+			if i < len(ids) {
+				r.ID = ids[i]
+			}
+
+			results[index] = r
+		}
+
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	single := len(indexes) == 1
+
+	// Only the items confirmed as not applied go on.
+	if opts.Unsure != nil && opts.Unsure(err) {
+		rest, settleErr := settle(ctx, items, indexes, key, opts, results, err)
+		if settleErr != nil || len(rest) == 0 {
+			return settleErr
+		}
+
+		indexes = rest
+		err = fmt.Errorf("%w (not applied)", err)
+	}
+
+	if single {
+		results[indexes[0]] = &Result{Key: key(items[indexes[0]]), Status: StatusFailed, Message: err.Error()}
+
+		return nil
+	}
+
+	// The batch was retried or looked up already; single items get one try
+	// each.
+	opts.Retries = 0
+
+	for _, index := range indexes {
+		if err := runBatch(ctx, items, []int{index}, key, fn, opts, results); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// settle looks up which items of a batch that failed with the unsure error
+// cause were applied anyway, marks those done and returns the others. When
+// that can't be confirmed, all items fail. The error is only set when ctx
+// is done.
+func settle[T any](ctx context.Context, items []T, indexes []int, key func(T) string, opts Options, results []*Result, cause error) ([]int, error) {
+	var (
+		found []int
+		err   = errors.New("no lookup")
+	)
+
+	if opts.Lookup != nil {
+		keys := make([]string, len(indexes))
+		for i, index := range indexes {
+			keys[i] = key(items[index])
+		}
+
+		found, err = opts.Lookup(ctx, keys)
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		for _, index := range indexes {
+			results[index] = &Result{
+				Key:     key(items[index]),
+				Status:  StatusFailed,
+				Message: fmt.Sprintf("%v (not retried: it may have been applied)", cause),
+			}
+		}
+
+		return nil, nil
+	}
+
+	var rest []int
+
+	for i, index := range indexes {
+		if i < len(found) && found[i] != 0 {
+			results[index] = &Result{Key: key(items[index]), Status: StatusDone, ID: found[i]}
+
+			continue
+		}
+
+		rest = append(rest, index)
+	}
+
+	return rest, nil
+}
+
+// attempt runs fn on batch, retrying with a growing delay. Unsure errors
+// aren't retried.
+func attempt[T any](ctx context.Context, batch []T, fn Func[T], opts Options) ([]int, error) {
+	delay := opts.Delay
+
+	for try := 0; ; try++ {
+		ids, err := call(ctx, batch, fn, opts.Timeout)
+		if err == nil || try >= opts.Retries || ctx.Err() != nil ||
+			opts.Retryable != nil && !opts.Retryable(err) || opts.Unsure != nil && opts.Unsure(err) {
+			return ids, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+func call[T any](ctx context.Context, batch []T, fn Func[T], timeout time.Duration) ([]int, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	return fn(ctx, batch)
+}
+
+// Count returns the number of results with status s.
+func Count(results []Result, s Status) int {
+	n := 0
+
+	for _, r := range results {
+		if r.Status == s {
+			n++
+		}
+	}
+
+	return n
+}
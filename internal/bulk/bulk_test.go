@@ -0,0 +1,266 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var (
+	errBad    = errors.New("bad item")
+	errFlaky  = errors.New("flaky")
+	errUnsure = errors.New("timeout")
+)
+
+// fakeOp applies a batch of item numbers, producing ID 100+n for item n.
+// fail returns the error for a batch, if any.
+type fakeOp struct {
+	calls   [][]int
+	applied []int
+	fail    func(call int, batch []int) error
+}
+
+func (f *fakeOp) run(_ context.Context, batch []int) ([]int, error) {
+	f.calls = append(f.calls, slices.Clone(batch))
+
+	if f.fail != nil {
+		if err := f.fail(len(f.calls), batch); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]int, len(batch))
+	for i, n := range batch {
+		f.applied = append(f.applied, n)
+		ids[i] = 100 + n
+	}
+
+	return ids, nil
+}
+
+func items(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i + 1
+	}
+
+	return out
+}
+
+// statuses renders results as "key:status:id" for comparison.
+func statuses(results []Result) []string {
+	out := make([]string, len(results))
+	for i, r := range results {
+		out[i] = r.Key + ":" + string(r.Status) + ":" + strconv.Itoa(r.ID)
+	}
+
+	return out
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name  string
+		n     int
+		opts  Options
+		fail  func(call int, batch []int) error
+		want  []string
+		calls int
+	}{
+		{
+			name:  "batches",
+			n:     5,
+			opts:  Options{BatchSize: 2},
+			want:  []string{"1:done:101", "2:done:102", "3:done:103", "4:done:104", "5:done:105"},
+			calls: 3,
+		},
+		{
+			name: "retry",
+			n:    2,
+			opts: Options{BatchSize: 2, Retries: 1},
+			fail: func(call int, _ []int) error {
+				if call == 1 {
+					return errFlaky
+				}
+
+				return nil
+			},
+			want:  []string{"1:done:101", "2:done:102"},
+			calls: 2,
+		},
+		{
+			name: "split after retries",
+			n:    3,
+			opts: Options{BatchSize: 3, Retries: 1},
+			fail: func(_ int, batch []int) error {
+				if slices.Contains(batch, 2) {
+					return errBad
+				}
+
+				return nil
+			},
+			want: []string{"1:done:101", "2:failed:0", "3:done:103"},
+			// Batch, its retry, then one try per item.
+			calls: 5,
+		},
+		{
+			name: "not retryable",
+			n:    1,
+			opts: Options{BatchSize: 1, Retries: 3, Retryable: func(err error) bool { return !errors.Is(err, errBad) }},
+			fail: func(int, []int) error { return errBad },
+			want: []string{"1:failed:0"},
+			// No retries.
+			calls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := &fakeOp{fail: tt.fail}
+
+			results, err := Run(context.Background(), items(tt.n), strconv.Itoa, op.run, tt.opts)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			if got := statuses(results); !slices.Equal(got, tt.want) {
+				t.Errorf("results = %v, want %v", got, tt.want)
+			}
+
+			if len(op.calls) != tt.calls {
+				t.Errorf("calls = %v, want %d", op.calls, tt.calls)
+			}
+		})
+	}
+}
+
+func TestRunUnsure(t *testing.T) {
+	isUnsure := func(err error) bool { return errors.Is(err, errUnsure) }
+
+	// The first call times out after applying item 1.
+	failFirst := func(op *fakeOp) func(int, []int) error {
+		return func(call int, batch []int) error {
+			if call == 1 {
+				op.applied = append(op.applied, batch[0])
+
+				return errUnsure
+			}
+
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		lookup  func(ctx context.Context, keys []string) ([]int, error)
+		want    []string
+		applied []int
+		message string
+	}{
+		{
+			name: "settled by lookup",
+			lookup: func(_ context.Context, keys []string) ([]int, error) {
+				ids := make([]int, len(keys))
+				ids[0] = 101
+
+				return ids, nil
+			},
+			want:    []string{"1:done:101", "2:done:102", "3:done:103"},
+			applied: []int{1, 2, 3},
+		},
+		{
+			name:    "no lookup",
+			want:    []string{"1:failed:0", "2:failed:0", "3:failed:0"},
+			applied: []int{1},
+			message: "not retried",
+		},
+		{
+			name: "lookup fails",
+			lookup: func(context.Context, []string) ([]int, error) {
+				return nil, errors.New("lookup failed")
+			},
+			want:    []string{"1:failed:0", "2:failed:0", "3:failed:0"},
+			applied: []int{1},
+			message: "not retried",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := &fakeOp{}
+			op.fail = failFirst(op)
+			opts := Options{BatchSize: 3, Retries: 2, Unsure: isUnsure, Lookup: tt.lookup}
+
+			results, err := Run(context.Background(), items(3), strconv.Itoa, op.run, opts)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			if got := statuses(results); !slices.Equal(got, tt.want) {
+				t.Errorf("results = %v, want %v", got, tt.want)
+			}
+
+			// Nothing is applied twice.
+			if !slices.Equal(op.applied, tt.applied) {
+				t.Errorf("applied = %v, want %v", op.applied, tt.applied)
+			}
+
+			for _, r := range results {
+				if r.Status == StatusFailed && !strings.Contains(r.Message, tt.message) {
+					t.Errorf("message = %q, want it to contain %q", r.Message, tt.message)
+				}
+			}
+		})
+	}
+}
+
+func TestRunUnsureSingleItem(t *testing.T) {
+	op := &fakeOp{fail: func(int, []int) error { return errUnsure }}
+	opts := Options{
+		BatchSize: 1,
+		Retries:   2,
+		Unsure:    func(error) bool { return true },
+		Lookup:    func(_ context.Context, keys []string) ([]int, error) { return make([]int, len(keys)), nil },
+	}
+
+	results, err := Run(context.Background(), items(1), strconv.Itoa, op.run, opts)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(op.calls) != 1 {
+		t.Errorf("calls = %v, want 1", op.calls)
+	}
+
+	if len(results) != 1 || results[0].Status != StatusFailed || !strings.Contains(results[0].Message, "not applied") {
+		t.Errorf("results = %+v, want one failure marked as not applied", results)
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	op := &fakeOp{}
+	op.fail = func(call int, _ []int) error {
+		if call == 2 {
+			cancel()
+
+			return ctx.Err()
+		}
+
+		return nil
+	}
+
+	results, err := Run(ctx, items(5), strconv.Itoa, op.run, Options{BatchSize: 2})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run error = %v, want context.Canceled", err)
+	}
+
+	// Unprocessed items are left out.
+	if got, want := statuses(results), []string{"1:done:101", "2:done:102"}; !slices.Equal(got, want) {
+		t.Errorf("results = %v, want %v", got, want)
+	}
+}
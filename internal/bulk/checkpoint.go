@@ -0,0 +1,121 @@
+package bulk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dedene/raindrop-cli/internal/config"
+)
+
+// Checkpoint records the items an operation has finished, keyed like
+// Result.Key, so a rerun with the same input skips them. A nil Checkpoint
+// records nothing.
+type Checkpoint struct {
+	path string
+
+	Op       string         `json:"op"`
+	Finished map[string]int `json:"finished"`
+	Updated  time.Time      `json:"updated"`
+}
+
+// NewCheckpoint returns an empty checkpoint for op, written to path. An
+// empty path picks a new file in the state directory.
+func NewCheckpoint(op, path string) (*Checkpoint, error) {
+	if path == "" {
+		dir, err := config.EnsureStateDir()
+		if err != nil {
+			return nil, err
+		}
+
+		path = filepath.Join(dir, fmt.Sprintf("%s-%s.checkpoint.json", op, time.Now().Format("20060102-150405")))
+	}
+
+	return &Checkpoint{path: path, Op: op, Finished: make(map[string]int)}, nil
+}
+
+// LoadCheckpoint reads the checkpoint at path, which must be for op.
+func LoadCheckpoint(op, path string) (*Checkpoint, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+
+	c := &Checkpoint{path: path}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("parse checkpoint %s: %w", path, err)
+	}
+
+	if c.Op != op {
+		return nil, fmt.Errorf("checkpoint %s is for %q, not %q", path, c.Op, op)
+	}
+
+	if c.Finished == nil {
+		c.Finished = make(map[string]int)
+	}
+
+	return c, nil
+}
+
+// Path returns the checkpoint file.
+func (c *Checkpoint) Path() string {
+	return c.path
+}
+
+// Done reports whether key is recorded as finished, with its ID.
+func (c *Checkpoint) Done(key string) (int, bool) {
+	if c == nil {
+		return 0, false
+	}
+
+	id, ok := c.Finished[key]
+
+	return id, ok
+}
+
+// Remove deletes the checkpoint file, e.g. once everything is done.
+func (c *Checkpoint) Remove() error {
+	if c == nil {
+		return nil
+	}
+
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// record adds the done results at indexes and writes the file.
+func (c *Checkpoint) record(results []*Result, indexes []int) error {
+	if c == nil {
+		return nil
+	}
+
+	for _, i := range indexes {
+		if r := results[i]; r != nil && r.Status == StatusDone {
+			c.Finished[r.Key] = r.ID
+		}
+	}
+
+	c.Updated = time.Now().UTC()
+
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode checkpoint: %w", err)
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+
+	return nil
+}
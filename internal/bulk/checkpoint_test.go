@@ -0,0 +1,91 @@
+package bulk
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "add.checkpoint.json")
+
+	cp, err := NewCheckpoint("add", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Item 2 fails in the first run.
+	first := &fakeOp{fail: func(_ int, batch []int) error {
+		if slices.Contains(batch, 2) {
+			return errBad
+		}
+
+		return nil
+	}}
+
+	if _, err := Run(context.Background(), items(3), strconv.Itoa, first.run, Options{BatchSize: 1, Checkpoint: cp}); err != nil {
+		t.Fatalf("first Run: %v", err)
+	}
+
+	cp, err = LoadCheckpoint("add", path)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint: %v", err)
+	}
+
+	if id, ok := cp.Done("1"); !ok || id != 101 {
+		t.Errorf("Done(1) = %d, %v; want 101, true", id, ok)
+	}
+
+	if _, ok := cp.Done("2"); ok {
+		t.Error("Done(2): failed item recorded as done")
+	}
+
+	second := &fakeOp{}
+
+	results, err := Run(context.Background(), items(3), strconv.Itoa, second.run, Options{BatchSize: 1, Checkpoint: cp})
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+
+	if !slices.Equal(second.applied, []int{2}) {
+		t.Errorf("resumed run applied %v, want [2]", second.applied)
+	}
+
+	want := []string{"1:skipped:101", "2:done:102", "3:skipped:103"}
+	if got := statuses(results); !slices.Equal(got, want) {
+		t.Errorf("results = %v, want %v", got, want)
+	}
+}
+
+func TestLoadCheckpointErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "delete.checkpoint.json")
+
+	cp, err := NewCheckpoint("delete", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cp.record(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadCheckpoint("add", path); err == nil {
+		t.Error("LoadCheckpoint: want an error for another operation")
+	}
+
+	if _, err := LoadCheckpoint("add", filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadCheckpoint: want an error for a missing file")
+	}
+
+	if err := cp.Remove(); err != nil {
+		t.Errorf("Remove: %v", err)
+	}
+
+	// A missing file is not an error.
+	if err := cp.Remove(); err != nil {
+		t.Errorf("Remove twice: %v", err)
+	}
+}
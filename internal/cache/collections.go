@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dedene/raindrop-cli/internal/api"
+	"github.com/dedene/raindrop-cli/internal/config"
+)
+
+// DefaultTTL is how long cached collections are considered fresh.
+const DefaultTTL = 10 * time.Minute
+
+const collectionsFile = "collections.json"
+
+// Collections stores the collections tree on disk so that name lookups
+// don't need to hit the API on every invocation.
+type Collections struct {
+	path string
+	ttl  time.Duration
+}
+
+type collectionsSnapshot struct {
+	FetchedAt time.Time        `json:"fetched_at"`
+	Items     []api.Collection `json:"items"`
+}
+
+// NewCollections returns a collections cache in the config cache directory.
+// A ttl of zero or less disables reads; writes still refresh the file.
+func NewCollections(ttl time.Duration) (*Collections, error) {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Collections{path: filepath.Join(dir, collectionsFile), ttl: ttl}, nil
+}
+
+// Load returns the cached collections if present and younger than the TTL.
+func (c *Collections) Load() ([]api.Collection, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	b, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, false
+	}
+
+	var snap collectionsSnapshot
+	if err := json.Unmarshal(b, &snap); err != nil {
+		return nil, false
+	}
+
+	if time.Since(snap.FetchedAt) > c.ttl {
+		return nil, false
+	}
+
+	return snap.Items, true
+}
+
+// Save writes collections to the cache.
+func (c *Collections) Save(collections []api.Collection) error {
+	if _, err := config.EnsureCacheDir(); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(collectionsSnapshot{FetchedAt: time.Now().UTC(), Items: collections})
+	if err != nil {
+		return fmt.Errorf("encode collections cache: %w", err)
+	}
+
+	tmp := c.path + ".tmp"
+
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("write collections cache: %w", err)
+	}
+
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("commit collections cache: %w", err)
+	}
+
+	return nil
+}
+
+// Invalidate removes the cached collections.
+func (c *Collections) Invalidate() error {
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove collections cache: %w", err)
+	}
+
+	return nil
+}
+
+// Clear removes all cached data, e.g. after switching accounts.
+func Clear() error {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("clear cache: %w", err)
+	}
+
+	return nil
+}
@@ -1,26 +1,51 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dedene/raindrop-cli/internal/api"
+	"github.com/dedene/raindrop-cli/internal/bulk"
+	"github.com/dedene/raindrop-cli/internal/config"
 	"github.com/dedene/raindrop-cli/internal/errfmt"
-	"github.com/dedene/raindrop-cli/internal/output"
+	"github.com/dedene/raindrop-cli/internal/input"
 )
 
 type AddCmd struct {
 	URL        string   `arg:"" optional:"" help:"URL to add (use - for stdin bulk)"`
-	Collection string   `help:"Collection name or ID" default:"-1" short:"c"`
+	Collection string   `help:"Collection name, path or ID" default:"-1" short:"c"`
 	Title      string   `help:"Override title" short:"t"`
 	Tags       []string `help:"Tags (repeat flag or comma-separated)" short:"T"`
 	Note       string   `help:"Note text" short:"n"`
 	NoFetch    bool     `help:"Skip fetching URL metadata" name:"no-fetch"`
+	// Bulk flags apply to stdin input (URL "-").
+	InputFormat string `help:"Stdin format: auto, lines (one URL per line), jsonl, csv or tsv" name:"input-format" enum:"auto,lines,jsonl,csv,tsv" default:"auto"`
+	Resume      string `help:"Continue a stdin bulk add from its checkpoint file, skipping the URLs already added" type:"path"`
+	Failures    string `help:"Write failed stdin items to this JSON Lines file (default: in the state directory)" type:"path"`
+}
+
+// addBatchSize is the number of raindrops created per request.
+const addBatchSize = 100
+
+// bulkAddOp names bulk add checkpoints.
+const bulkAddOp = "add"
+
+// addItem is a stdin item with its create request.
+type addItem struct {
+	item input.Item
+	req  api.CreateRaindropRequest
 }
 
 func (c *AddCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
@@ -66,76 +91,269 @@ func (c *AddCmd) Run(flags *RootFlags) error {
 		return errfmt.Format(err)
 	}
 
-	if flags.JSON {
-		return output.WriteJSON(os.Stdout, raindrop)
-	}
-
-	fmt.Fprintf(os.Stdout, "Added: %s (ID: %d)\n", raindrop.Title, raindrop.ID)
+	table := raindropTable(flags, *raindrop)
 
-	return nil
+	return newRenderer(flags).Item(raindrop, table, func(w io.Writer) {
+		fmt.Fprintf(w, "Added: %s (ID: %d)\n", raindrop.Title, raindrop.ID)
+	})
 }
 
+// runBulk creates the bookmarks read from stdin. --collection applies to
+// items without one, and --tags are added to the item tags. Invalid lines
+// are reported and skipped, failed batches are retried and then split, and
+// progress is checkpointed for --resume.
 func (c *AddCmd) runBulk(client *api.Client, flags *RootFlags, collectionID int) error {
-	urls, err := readURLsFromStdin()
+	items, lineErrs, err := input.Read(os.Stdin, c.InputFormat)
 	if err != nil {
-		return err
+		return &ExitError{Code: ExitUsage, Err: err}
 	}
 
-	if len(urls) == 0 {
-		return fmt.Errorf("no URLs provided on stdin")
+	if len(items) == 0 && len(lineErrs) == 0 {
+		return fmt.Errorf("no bookmarks on stdin")
 	}
 
-	_, ctx, cancel, err := getClientWithContext()
+	checkpoint, err := c.checkpoint()
 	if err != nil {
-		return errfmt.Format(err)
+		return err
 	}
-	defer cancel()
 
-	// Build requests
-	items := make([]api.CreateRaindropRequest, 0, len(urls))
+	summary := &bulkSummary{verb: "Added", noun: "raindrop"}
+
+	for _, e := range lineErrs {
+		summary.invalid = append(summary.invalid, bulkIssue{label: fmt.Sprintf("line %d", e.Line), err: e.Err.Error()})
+	}
+
+	ctx := flags.Context()
+	adds := make([]addItem, 0, len(items))
+	// Items are keyed by input line, as the same link may appear twice.
+	byKey := make(map[string]addItem, len(items))
+	collections := map[string]resolvedCollection{"": {id: collectionID}}
+
+	var failed []input.Item
+
+	for _, item := range items {
+		req, err := c.request(ctx, client, item, collections)
+		if err != nil {
+			summary.failed = append(summary.failed, bulkIssue{label: itemLabel(item), err: err.Error()})
+			failed = append(failed, item)
+
+			continue
+		}
+
+		a := addItem{item: item, req: req}
+		adds = append(adds, a)
+		byKey[a.key()] = a
+	}
+
+	// Creating is not idempotent: after an unsure error, only the links
+	// that weren't saved are tried again.
+	opts := bulkOptions(addBatchSize)
+	opts.Checkpoint = checkpoint
+	opts.Unsure = unsure
+	opts.Lookup = func(ctx context.Context, keys []string) ([]int, error) {
+		links := make([]string, len(keys))
+		for i, key := range keys {
+			links[i] = byKey[key].item.Link
+		}
+
+		return linkIDs(ctx, client, links)
+	}
+
+	progress := newProgress(flags, "Adding raindrops", "raindrops", int64(len(adds)))
+	opts.Progress = func(finished, _ int) { progress.Set(int64(finished)) }
+
+	var created []api.Raindrop
+
+	results, runErr := bulk.Run(ctx, adds, addItem.key,
+		func(ctx context.Context, batch []addItem) ([]int, error) {
+			reqs := make([]api.CreateRaindropRequest, len(batch))
+			for i, a := range batch {
+				reqs[i] = a.req
+			}
+
+			items, err := client.CreateRaindropsBulk(ctx, reqs)
+			if err != nil {
+				return nil, err
+			}
+
+			ids := make([]int, len(items))
+			for i := range items {
+				ids[i] = items[i].ID
+			}
+
+			created = append(created, items...)
+
+			return ids, nil
+		},
+		opts)
+
+	progress.Done()
+	summary.add(results, func(key string) string { return itemLabel(byKey[key].item) })
+
+	for _, r := range results {
+		if r.Status == bulk.StatusFailed {
+			failed = append(failed, byKey[r.Key].replay())
+		}
+	}
+
+	// On an interrupt, report what was done and keep the checkpoint.
+	interrupted := errors.Is(runErr, context.Canceled)
+	if runErr != nil && !interrupted {
+		return errfmt.Format(runErr)
+	}
+
+	summary.unprocessed = len(adds) - len(results)
+
+	if err := c.writeFailures(summary, failed); err != nil {
+		return err
+	}
+
+	if len(failed) == 0 && !interrupted {
+		_ = checkpoint.Remove()
+	} else {
+		summary.hints = append(summary.hints, fmt.Sprintf("Progress saved to %s; resume with the same input and: raindrop add - --resume %s", checkpoint.Path(), checkpoint.Path()))
+	}
+
+	if r := newRenderer(flags); !r.Human() {
+		if err := r.List(created, raindropTable(flags, created...)); err != nil {
+			return err
+		}
+	}
+
+	summary.print(summaryWriter(flags))
+
+	if interrupted {
+		return runErr
+	}
+
+	return summary.err()
+}
+
+// linkIDs returns the ID saved for each link, or 0, with one request each.
+func linkIDs(ctx context.Context, client *api.Client, links []string) ([]int, error) {
+	ids := make([]int, len(links))
+
+	for i, link := range links {
+		lookupCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+		id, err := client.LinkID(lookupCtx, link)
+
+		cancel()
 
-	for _, u := range urls {
-		req := api.CreateRaindropRequest{
-			Link:        u,
-			Tags:        c.normalizeTags(),
-			PleaseParse: true,
+		if err != nil {
+			return nil, err
 		}
-		req.Collection.ID = collectionID
 
-		items = append(items, req)
+		ids[i] = id
 	}
 
-	// Batch in groups of 100
-	var allCreated []api.Raindrop
+	return ids, nil
+}
 
-	batchSize := 100
+// itemLabel identifies a stdin item in reports.
+func itemLabel(item input.Item) string {
+	return fmt.Sprintf("line %d %s", item.Line, item.Link)
+}
 
-	for i := 0; i < len(items); i += batchSize {
-		end := min(i+batchSize, len(items))
+// key identifies the item by its input line, for results and checkpoints.
+func (a addItem) key() string {
+	return strconv.Itoa(a.item.Line)
+}
+
+// replay returns the item with its effective collection and tags, so it can
+// be added again on its own.
+func (a addItem) replay() input.Item {
+	item := a.item
+	item.Collection = strconv.Itoa(a.req.Collection.ID)
+	item.Tags = a.req.Tags
 
-		batch := items[i:end]
+	return item
+}
 
-		created, err := client.CreateRaindropsBulk(ctx, batch)
+// checkpoint returns the --resume checkpoint, or a new one.
+func (c *AddCmd) checkpoint() (*bulk.Checkpoint, error) {
+	if c.Resume != "" {
+		cp, err := bulk.LoadCheckpoint(bulkAddOp, c.Resume)
 		if err != nil {
-			return errfmt.Format(err)
+			return nil, &ExitError{Code: ExitUsage, Err: err}
 		}
 
-		allCreated = append(allCreated, created...)
+		return cp, nil
+	}
+
+	return bulk.NewCheckpoint(bulkAddOp, "")
+}
+
+// writeFailures writes the failed items as JSON Lines that add - accepts.
+func (c *AddCmd) writeFailures(summary *bulkSummary, failed []input.Item) error {
+	if len(failed) == 0 {
+		return nil
+	}
 
-		if !flags.JSON {
-			fmt.Fprintf(os.Stderr, "Created %d/%d raindrops\n", len(allCreated), len(items))
+	path := c.Failures
+	if path == "" {
+		dir, err := config.EnsureStateDir()
+		if err != nil {
+			return err
 		}
+
+		path = filepath.Join(dir, fmt.Sprintf("%s-failures-%s.jsonl", bulkAddOp, time.Now().Format("20060102-150405")))
 	}
 
-	if flags.JSON {
-		return output.WriteJSON(os.Stdout, allCreated)
+	var buf bytes.Buffer
+	if err := input.WriteJSONL(&buf, failed); err != nil {
+		return err
 	}
 
-	fmt.Fprintf(os.Stdout, "Added %d raindrops\n", len(allCreated))
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("write failures: %w", err)
+	}
+
+	summary.hints = append(summary.hints, fmt.Sprintf("Failed items written to %s; retry with: raindrop add - < %s", path, path))
 
 	return nil
 }
 
+// resolvedCollection is the result of resolving a stdin collection name.
+type resolvedCollection struct {
+	id  int
+	err error
+}
+
+// request builds the create request for a stdin item. collections caches
+// the result of resolving each collection name, errors included, so a bad
+// name is looked up once.
+func (c *AddCmd) request(ctx context.Context, client *api.Client, item input.Item, collections map[string]resolvedCollection) (api.CreateRaindropRequest, error) {
+	resolved, ok := collections[item.Collection]
+	if !ok {
+		resolveCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+		resolved.id, resolved.err = client.ResolveCollection(resolveCtx, item.Collection)
+
+		cancel()
+
+		collections[item.Collection] = resolved
+	}
+
+	if resolved.err != nil {
+		return api.CreateRaindropRequest{}, resolved.err
+	}
+
+	req := api.CreateRaindropRequest{
+		Link:      item.Link,
+		Title:     item.Title,
+		Note:      item.Note,
+		Tags:      api.EditTags(item.Tags, c.normalizeTags(), nil),
+		Important: item.Important,
+	}
+	req.Collection.ID = resolved.id
+	req.PleaseParse = !c.NoFetch && req.Title == ""
+
+	if !item.Created.IsZero() {
+		req.Created = &item.Created
+	}
+
+	return req, nil
+}
+
 func (c *AddCmd) normalizeTags() []string {
 	var tags []string
 
@@ -12,8 +12,10 @@ import (
 
 	"github.com/dedene/raindrop-cli/internal/api"
 	"github.com/dedene/raindrop-cli/internal/auth"
+	"github.com/dedene/raindrop-cli/internal/cache"
 	"github.com/dedene/raindrop-cli/internal/config"
 	"github.com/dedene/raindrop-cli/internal/errfmt"
+	"github.com/dedene/raindrop-cli/internal/state"
 )
 
 type AuthCmd struct {
@@ -118,6 +120,9 @@ func (c *AuthTokenCmd) Run() error {
 		return fmt.Errorf("store token: %w", err)
 	}
 
+	_ = cache.Clear() // cached data may belong to another account
+	_ = state.Clear()
+
 	fmt.Fprintln(os.Stdout, "Token saved successfully.")
 	fmt.Fprintln(os.Stdout, "Run 'raindrop auth status' to verify.")
 
@@ -128,7 +133,7 @@ type AuthLoginCmd struct {
 	Manual bool `help:"Manual authorization (paste URL instead of callback server)"`
 }
 
-func (c *AuthLoginCmd) Run() error {
+func (c *AuthLoginCmd) Run(flags *RootFlags) error {
 	store, err := auth.OpenDefault()
 	if err != nil {
 		return fmt.Errorf("open keyring: %w", err)
@@ -148,7 +153,7 @@ func (c *AuthLoginCmd) Run() error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx := flags.Context()
 	refreshToken, err := auth.Authorize(ctx, creds, auth.AuthorizeOptions{
 		Manual:  c.Manual,
 		Timeout: 3 * time.Minute,
@@ -168,6 +173,9 @@ func (c *AuthLoginCmd) Run() error {
 		return fmt.Errorf("store token: %w", storeErr)
 	}
 
+	_ = cache.Clear() // cached data may belong to another account
+	_ = state.Clear()
+
 	ts := auth.NewRefreshTokenSource(creds, refreshToken)
 	client := api.NewClient(ts)
 	user, fetchErr := c.fetchUser(ctx, client)
@@ -191,12 +199,12 @@ func (c *AuthLoginCmd) fetchUser(ctx context.Context, client *api.Client) (*api.
 
 type AuthStatusCmd struct{}
 
-func (c *AuthStatusCmd) Run() error {
+func (c *AuthStatusCmd) Run(flags *RootFlags) error {
 	// Check environment variable first
 	if os.Getenv("RAINDROP_TOKEN") != "" {
 		fmt.Fprintln(os.Stdout, "Using token from RAINDROP_TOKEN environment variable")
 
-		return c.verifyToken()
+		return c.verifyToken(flags.Context())
 	}
 
 	store, err := auth.OpenDefault()
@@ -225,7 +233,7 @@ func (c *AuthStatusCmd) Run() error {
 	if tok.TestToken != "" {
 		fmt.Fprintf(os.Stdout, "Authenticated with test token (since %s)\n", tok.CreatedAt.Format("2006-01-02"))
 
-		return c.verifyToken()
+		return c.verifyToken(flags.Context())
 	}
 
 	if tok.RefreshToken != "" {
@@ -238,7 +246,7 @@ func (c *AuthStatusCmd) Run() error {
 
 		fmt.Fprintf(os.Stdout, "Authenticated with OAuth (since %s)\n", tok.CreatedAt.Format("2006-01-02"))
 
-		return c.verifyToken()
+		return c.verifyToken(flags.Context())
 	}
 
 	if credsConfigured {
@@ -254,13 +262,13 @@ func (c *AuthStatusCmd) Run() error {
 	return nil
 }
 
-func (c *AuthStatusCmd) verifyToken() error {
+func (c *AuthStatusCmd) verifyToken(ctx context.Context) error {
 	client, err := api.NewClientFromAuth()
 	if err != nil {
 		return errfmt.Format(err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	user, err := client.GetUser(ctx)
@@ -291,6 +299,9 @@ func (c *AuthLogoutCmd) Run() error {
 		return fmt.Errorf("remove token: %w", err)
 	}
 
+	_ = cache.Clear()
+	_ = state.Clear()
+
 	fmt.Fprintln(os.Stdout, "Logged out successfully.")
 
 	return nil
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/dedene/raindrop-cli/internal/api"
+	"github.com/dedene/raindrop-cli/internal/bulk"
+	"github.com/dedene/raindrop-cli/internal/output"
+)
+
+// bulkOptions returns the usual bulk settings for batches of size n.
+func bulkOptions(n int) bulk.Options {
+	return bulk.Options{
+		BatchSize: n,
+		Retries:   bulk.DefaultRetries,
+		Delay:     bulk.DefaultDelay,
+		Retryable: retryable,
+		Timeout:   defaultTimeout,
+	}
+}
+
+// retryable reports whether a failed request is worth repeating: not when
+// the API rejected it as invalid, unauthorized or missing.
+func retryable(err error) bool {
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var authErr *api.AuthError
+
+	var notFound *api.NotFoundError
+
+	return !errors.As(err, &authErr) && !errors.As(err, &notFound)
+}
+
+// unsure reports whether a failed request may have been applied anyway: it
+// got no definite answer from the API, as after a timeout, a dropped
+// connection or a server error.
+func unsure(err error) bool {
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var authErr *api.AuthError
+
+	var notFound *api.NotFoundError
+
+	var rateLimit *api.RateLimitError
+
+	return !errors.As(err, &authErr) && !errors.As(err, &notFound) && !errors.As(err, &rateLimit)
+}
+
+// bulkSummary collects the outcome of a bulk command for the final report.
+type bulkSummary struct {
+	// verb and noun describe the done items, as in "Added 3 raindrops".
+	verb, noun string
+
+	done, skipped int
+	invalid       []bulkIssue
+	failed        []bulkIssue
+	// unprocessed counts the items left when the command was interrupted.
+	unprocessed int
+	// hints are printed last, e.g. where failed items were written.
+	hints []string
+}
+
+// bulkIssue is an invalid or failed item.
+type bulkIssue struct {
+	label string
+	err   string
+}
+
+// add counts results, turning failed ones into issues labelled by label.
+func (s *bulkSummary) add(results []bulk.Result, label func(key string) string) {
+	for _, r := range results {
+		switch r.Status {
+		case bulk.StatusDone:
+			s.done++
+		case bulk.StatusSkipped:
+			s.skipped++
+		case bulk.StatusFailed:
+			s.failed = append(s.failed, bulkIssue{label: label(r.Key), err: r.Message})
+		}
+	}
+}
+
+// print writes the report to w: counts, then each invalid and failed item,
+// then the hints.
+func (s *bulkSummary) print(w io.Writer) {
+	line := fmt.Sprintf("%s %d %s(s)", s.verb, s.done, s.noun)
+
+	var extra []string
+
+	if s.skipped > 0 {
+		extra = append(extra, fmt.Sprintf("%d already done", s.skipped))
+	}
+
+	if n := len(s.invalid); n > 0 {
+		extra = append(extra, fmt.Sprintf("%d invalid", n))
+	}
+
+	if n := len(s.failed); n > 0 {
+		extra = append(extra, output.StyleRed(fmt.Sprintf("%d failed", n)))
+	}
+
+	if s.unprocessed > 0 {
+		extra = append(extra, output.StyleYellow(fmt.Sprintf("%d not processed (interrupted)", s.unprocessed)))
+	}
+
+	if len(extra) > 0 {
+		line += "; " + strings.Join(extra, ", ")
+	}
+
+	fmt.Fprintln(w, line)
+
+	for _, issue := range s.invalid {
+		fmt.Fprintf(w, "  %s %s: %s\n", output.StyleYellow("invalid"), issue.label, issue.err)
+	}
+
+	for _, issue := range s.failed {
+		fmt.Fprintf(w, "  %s %s: %s\n", output.StyleRed("failed"), issue.label, firstLine(issue.err))
+	}
+
+	for _, hint := range s.hints {
+		fmt.Fprintln(w, hint)
+	}
+}
+
+// err returns an error when items were invalid or failed.
+func (s *bulkSummary) err() error {
+	if len(s.invalid) == 0 && len(s.failed) == 0 {
+		return nil
+	}
+
+	return &ExitError{Code: ExitGeneric, Err: fmt.Errorf("%d of %d %s(s) failed", len(s.invalid)+len(s.failed), s.total(), s.noun)}
+}
+
+func (s *bulkSummary) total() int {
+	return s.done + s.skipped + len(s.invalid) + len(s.failed) + s.unprocessed
+}
+
+// summaryWriter is stdout in the human view; structured output keeps stdout
+// for data, so the report goes to stderr.
+func summaryWriter(flags *RootFlags) io.Writer {
+	if newRenderer(flags).Human() {
+		return os.Stdout
+	}
+
+	return os.Stderr
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+
+	return line
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/dedene/raindrop-cli/internal/api"
@@ -22,29 +23,28 @@ type CollectionsListCmd struct {
 }
 
 func (c *CollectionsListCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
 	defer cancel()
 
-	collections, err := client.ListAllCollections(ctx)
+	// Always fetch fresh data here; this also refreshes the lookup cache.
+	collections, err := client.RefreshCollections(ctx)
 	if err != nil {
 		return errfmt.Format(err)
 	}
 
-	if flags.JSON {
-		return output.WriteJSON(os.Stdout, collections)
-	}
+	r := newRenderer(flags)
 
-	if c.Flat {
-		tw := output.NewTableWriter(os.Stdout, output.CollectionTableHeaders()...)
-		for _, col := range collections {
-			tw.AddRow(output.FormatCollectionRow(&col)...)
+	if c.Flat || !r.Human() {
+		if err := r.List(collections, output.CollectionTable(collections, api.CollectionPaths(collections))); err != nil {
+			return err
 		}
 
-		tw.Render()
-		fmt.Fprintf(os.Stdout, "\n%d collection(s)\n", len(collections))
+		if r.Human() {
+			fmt.Fprintf(os.Stdout, "\n%d collection(s)\n", len(collections))
+		}
 
 		return nil
 	}
@@ -57,11 +57,11 @@ func (c *CollectionsListCmd) Run(flags *RootFlags) error {
 }
 
 type CollectionsGetCmd struct {
-	Collection string `arg:"" help:"Collection name or ID"`
+	Collection string `arg:"" help:"Collection name, path or ID"`
 }
 
 func (c *CollectionsGetCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
@@ -84,23 +84,27 @@ func (c *CollectionsGetCmd) Run(flags *RootFlags) error {
 		return errfmt.Format(err)
 	}
 
-	if flags.JSON {
-		return output.WriteJSON(os.Stdout, collection)
+	// Parent names are cosmetic; fall back to IDs if the tree is unavailable.
+	var paths map[int]string
+	if all, listErr := client.Collections(ctx); listErr == nil {
+		paths = api.CollectionPaths(all)
 	}
 
-	output.FormatCollectionDetail(os.Stdout, collection)
+	table := output.CollectionTable([]api.Collection{*collection}, paths)
 
-	return nil
+	return newRenderer(flags).Item(collection, table, func(w io.Writer) {
+		output.FormatCollectionDetail(w, collection, paths)
+	})
 }
 
 type CollectionsCreateCmd struct {
 	Name   string `arg:"" help:"Collection name"`
-	Parent string `help:"Parent collection name/ID" short:"p"`
+	Parent string `help:"Parent collection name, path or ID" short:"p"`
 	Color  string `help:"Color hex code (e.g., #ff0000)" short:"c"`
 }
 
 func (c *CollectionsCreateCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
@@ -127,23 +131,21 @@ func (c *CollectionsCreateCmd) Run(flags *RootFlags) error {
 		return errfmt.Format(err)
 	}
 
-	if flags.JSON {
-		return output.WriteJSON(os.Stdout, collection)
-	}
-
-	fmt.Fprintf(os.Stdout, "Created: %s (ID: %d)\n", collection.Title, collection.ID)
+	table := output.CollectionTable([]api.Collection{*collection}, nil)
 
-	return nil
+	return newRenderer(flags).Item(collection, table, func(w io.Writer) {
+		fmt.Fprintf(w, "Created: %s (ID: %d)\n", collection.Title, collection.ID)
+	})
 }
 
 type CollectionsUpdateCmd struct {
-	Collection string `arg:"" help:"Collection name or ID"`
+	Collection string `arg:"" help:"Collection name, path or ID"`
 	Name       string `help:"New name" short:"n"`
 	Color      string `help:"New color" short:"c"`
 }
 
 func (c *CollectionsUpdateCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
@@ -172,21 +174,19 @@ func (c *CollectionsUpdateCmd) Run(flags *RootFlags) error {
 		return errfmt.Format(err)
 	}
 
-	if flags.JSON {
-		return output.WriteJSON(os.Stdout, collection)
-	}
-
-	fmt.Fprintf(os.Stdout, "Updated: %s (ID: %d)\n", collection.Title, collection.ID)
+	table := output.CollectionTable([]api.Collection{*collection}, nil)
 
-	return nil
+	return newRenderer(flags).Item(collection, table, func(w io.Writer) {
+		fmt.Fprintf(w, "Updated: %s (ID: %d)\n", collection.Title, collection.ID)
+	})
 }
 
 type CollectionsDeleteCmd struct {
-	Collection string `arg:"" help:"Collection name or ID"`
+	Collection string `arg:"" help:"Collection name, path or ID"`
 }
 
 func (c *CollectionsDeleteCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
@@ -0,0 +1,489 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/dedene/raindrop-cli/internal/api"
+	"github.com/dedene/raindrop-cli/internal/config"
+	"github.com/dedene/raindrop-cli/internal/output"
+)
+
+// completionTimeout bounds API lookups so a slow network never hangs the shell.
+const completionTimeout = 5 * time.Second
+
+// fileCompletionDirective, printed as the only line, asks the shell script to
+// fall back to its native file name completion.
+const fileCompletionDirective = ":files"
+
+// emptyWordMarker stands in for an empty current word. Some shells (older
+// PowerShell) drop empty arguments when invoking native commands.
+const emptyWordMarker = `""`
+
+// CompleteCmd implements the protocol used by the shell scripts:
+//
+//	raindrop __complete -- <words...>
+//
+// The words are the command line after "raindrop"; the last one is the word
+// being completed (possibly empty). Candidates are printed one per line as
+// "value<TAB>description", or fileCompletionDirective alone when the shell
+// should complete file names. Commands, flags and enum values come from the
+// kong model, so new commands complete without touching the scripts.
+type CompleteCmd struct {
+	Words []string `arg:"" optional:"" passthrough:"all" help:"Command line words; the last one is completed"`
+}
+
+type completion struct {
+	Value       string
+	Description string
+}
+
+func (c *CompleteCmd) Run(kctx *kong.Context, flags *RootFlags) error {
+	words := normalizeCompletionWords(c.Words)
+	if len(words) == 0 {
+		words = []string{""}
+	}
+
+	for _, cand := range completeWords(kctx.Model.Node, words, &completionSource{flags: flags}) {
+		if cand.Description == "" {
+			fmt.Fprintln(os.Stdout, cand.Value)
+
+			continue
+		}
+
+		fmt.Fprintf(os.Stdout, "%s\t%s\n", cand.Value, oneLine(cand.Description))
+	}
+
+	return nil
+}
+
+// normalizeCompletionWords drops the leading "--" separator and rejoins
+// "--flag = value" sequences produced by bash's COMP_WORDBREAKS.
+func normalizeCompletionWords(words []string) []string {
+	if len(words) > 0 && words[0] == "--" {
+		words = words[1:]
+	}
+
+	out := make([]string, 0, len(words))
+
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+
+		if w == "=" && len(out) > 0 && strings.HasPrefix(out[len(out)-1], "-") {
+			next := ""
+			if i+1 < len(words) {
+				next = words[i+1]
+				i++
+			}
+
+			out[len(out)-1] += "=" + next
+
+			continue
+		}
+
+		out = append(out, w)
+	}
+
+	if n := len(out); n > 0 && out[n-1] == emptyWordMarker {
+		out[n-1] = ""
+	}
+
+	return out
+}
+
+// completionState is the parser position reached after consuming all words
+// before the one being completed.
+type completionState struct {
+	node       *kong.Node
+	pending    *kong.Flag // flag whose value is being completed
+	positional int        // index of the positional argument being completed
+}
+
+func walkCompletionWords(root *kong.Node, words []string) completionState {
+	st := completionState{node: root}
+
+	for _, w := range words {
+		if st.pending != nil {
+			st.pending = nil
+
+			continue
+		}
+
+		switch {
+		case w == "--":
+			continue
+		case strings.HasPrefix(w, "--"):
+			name, _, hasValue := strings.Cut(strings.TrimPrefix(w, "--"), "=")
+			if f := findFlag(st.node, name); f != nil && !hasValue && takesValue(f) {
+				st.pending = f
+			}
+		case strings.HasPrefix(w, "-") && len(w) > 1:
+			runes := []rune(w[1:])
+			if f := findShortFlag(st.node, runes[len(runes)-1]); f != nil && takesValue(f) {
+				st.pending = f
+			}
+		default:
+			if child := findChild(st.node, w); child != nil && st.positional == 0 {
+				st.node = child
+
+				continue
+			}
+
+			st.positional++
+		}
+	}
+
+	return st
+}
+
+func completeWords(root *kong.Node, words []string, src *completionSource) []completion {
+	cur := words[len(words)-1]
+	st := walkCompletionWords(root, words[:len(words)-1])
+
+	if st.pending != nil {
+		return completeValue(st.pending.Value, cur, src)
+	}
+
+	if strings.HasPrefix(cur, "--") && strings.Contains(cur, "=") {
+		name, value, _ := strings.Cut(strings.TrimPrefix(cur, "--"), "=")
+		if f := findFlag(st.node, name); f != nil {
+			cands := completeValue(f.Value, value, src)
+			if isFileDirective(cands) {
+				return cands
+			}
+
+			return prefixCompletions("--"+name+"=", cands)
+		}
+
+		return nil
+	}
+
+	if strings.HasPrefix(cur, "-") {
+		return completeFlags(st.node, cur)
+	}
+
+	var cands []completion
+
+	if st.positional == 0 {
+		cands = completeCommands(st.node, cur)
+	}
+
+	if arg := positionalAt(st.node, st.positional); arg != nil {
+		cands = append(cands, completeValue(arg, cur, src)...)
+	}
+
+	return cands
+}
+
+// completeValue completes the value of a flag or positional argument from its
+// enum, its type or, for known names, the API.
+func completeValue(v *kong.Value, cur string, src *completionSource) []completion {
+	if enum := v.EnumSlice(); v.Enum != "" && len(enum) > 0 {
+		cands := make([]completion, 0, len(enum))
+		for _, e := range enum {
+			cands = append(cands, completion{Value: e})
+		}
+
+		return filterCompletions(cands, cur, false)
+	}
+
+	if v.Tag != nil {
+		switch v.Tag.Type {
+		case "existingfile", "existingdir", "path":
+			return []completion{{Value: fileCompletionDirective}}
+		}
+	}
+
+	return src.values(v.Name, cur)
+}
+
+func completeCommands(node *kong.Node, cur string) []completion {
+	var cands []completion
+
+	for _, child := range node.Children {
+		if child.Type != kong.CommandNode || child.Hidden {
+			continue
+		}
+
+		cands = append(cands, completion{Value: child.Name, Description: child.Help})
+	}
+
+	return filterCompletions(cands, cur, false)
+}
+
+func completeFlags(node *kong.Node, cur string) []completion {
+	var cands []completion
+
+	for _, group := range node.AllFlags(true) {
+		for _, f := range group {
+			cands = append(cands, completion{Value: "--" + f.Name, Description: f.Help})
+
+			if f.Negated {
+				cands = append(cands, completion{Value: "--no-" + f.Name, Description: f.Help})
+			}
+
+			if f.Short != 0 && !strings.HasPrefix(cur, "--") {
+				cands = append(cands, completion{Value: "-" + string(f.Short), Description: f.Help})
+			}
+		}
+	}
+
+	return filterCompletions(cands, cur, false)
+}
+
+func isFileDirective(cands []completion) bool {
+	return len(cands) == 1 && cands[0].Value == fileCompletionDirective
+}
+
+func findFlag(node *kong.Node, name string) *kong.Flag {
+	for _, group := range node.AllFlags(false) {
+		for _, f := range group {
+			if f.Name == name {
+				return f
+			}
+
+			for _, alias := range f.Aliases {
+				if alias == name {
+					return f
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func findShortFlag(node *kong.Node, short rune) *kong.Flag {
+	for _, group := range node.AllFlags(false) {
+		for _, f := range group {
+			if f.Short == short {
+				return f
+			}
+		}
+	}
+
+	return nil
+}
+
+func findChild(node *kong.Node, name string) *kong.Node {
+	for _, child := range node.Children {
+		if child.Type != kong.CommandNode {
+			continue
+		}
+
+		if child.Name == name {
+			return child
+		}
+
+		for _, alias := range child.Aliases {
+			if alias == name {
+				return child
+			}
+		}
+	}
+
+	return nil
+}
+
+func positionalAt(node *kong.Node, index int) *kong.Positional {
+	if len(node.Positional) == 0 {
+		return nil
+	}
+
+	if index < len(node.Positional) {
+		return node.Positional[index]
+	}
+
+	if last := node.Positional[len(node.Positional)-1]; last.IsCumulative() {
+		return last
+	}
+
+	return nil
+}
+
+func takesValue(f *kong.Flag) bool {
+	return !f.IsBool() && !f.IsCounter()
+}
+
+func prefixCompletions(prefix string, cands []completion) []completion {
+	for i := range cands {
+		cands[i].Value = prefix + cands[i].Value
+	}
+
+	return cands
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// completionSource looks up dynamic values. Lookups are best effort: any
+// error (not authenticated, offline, ...) simply yields no candidates.
+type completionSource struct {
+	flags  *RootFlags
+	client *api.Client
+}
+
+// values completes the value of a flag or positional argument by its name.
+func (s *completionSource) values(name, cur string) []completion {
+	if strings.HasPrefix(cur, savedSearchPrefix) && (name == "collection" || name == "query") {
+		return s.savedSearches(cur)
+	}
+
+	switch name {
+	case "collection", "parent":
+		return s.collections(cur)
+	case "tag", "tags", "exclude-tag":
+		return s.tags(cur)
+	case "id", "raindrop-id":
+		return s.raindrops(cur)
+	}
+
+	return nil
+}
+
+func (s *completionSource) withClient(fn func(ctx context.Context, client *api.Client)) {
+	if s.client == nil {
+		client, err := getClient(s.flags)
+		if err != nil {
+			return
+		}
+
+		s.client = client
+	}
+
+	ctx, cancel := context.WithTimeout(s.flags.Context(), completionTimeout)
+	defer cancel()
+
+	fn(ctx, s.client)
+}
+
+func (s *completionSource) collections(cur string) []completion {
+	cands := []completion{
+		{Value: "all", Description: "All raindrops"},
+		{Value: "unsorted", Description: "Unsorted"},
+		{Value: "trash", Description: "Trash"},
+	}
+
+	s.withClient(func(ctx context.Context, client *api.Client) {
+		collections, err := client.Collections(ctx)
+		if err != nil {
+			return
+		}
+
+		paths := api.CollectionPaths(collections)
+
+		titles := make(map[string]int, len(collections))
+		for _, col := range collections {
+			titles[strings.ToLower(col.Title)]++
+		}
+
+		// Offer bare names where they are unambiguous, full paths otherwise
+		// or once the user starts typing a path.
+		for _, col := range collections {
+			value := paths[col.ID]
+			if titles[strings.ToLower(col.Title)] == 1 && !strings.Contains(cur, "/") {
+				value = api.EscapeCollectionTitle(col.Title)
+			}
+
+			cands = append(cands, completion{
+				Value:       value,
+				Description: fmt.Sprintf("%s (%d)", paths[col.ID], col.Count),
+			})
+		}
+	})
+
+	return filterCompletions(cands, cur, true)
+}
+
+func (s *completionSource) tags(cur string) []completion {
+	// Comma-separated lists complete the last element.
+	head := ""
+	if i := strings.LastIndex(cur, ","); i >= 0 {
+		head, cur = cur[:i+1], cur[i+1:]
+	}
+
+	var cands []completion
+
+	s.withClient(func(ctx context.Context, client *api.Client) {
+		tags, err := client.ListTags(ctx, api.SystemCollectionAll)
+		if err != nil {
+			return
+		}
+
+		for _, t := range tags {
+			cands = append(cands, completion{Value: t.Tag, Description: fmt.Sprintf("%d raindrop(s)", t.Count)})
+		}
+	})
+
+	return prefixCompletions(head, filterCompletions(cands, cur, true))
+}
+
+func (s *completionSource) raindrops(cur string) []completion {
+	if cur != "" {
+		if _, err := strconv.Atoi(cur); err != nil {
+			return nil
+		}
+	}
+
+	var cands []completion
+
+	s.withClient(func(ctx context.Context, client *api.Client) {
+		resp, err := client.ListRaindrops(ctx, api.SystemCollectionAll, api.ListOptions{Sort: "-created"})
+		if err != nil {
+			return
+		}
+
+		for _, r := range resp.Items {
+			cands = append(cands, completion{Value: strconv.Itoa(r.ID), Description: r.Title})
+		}
+	})
+
+	return filterCompletions(cands, cur, false)
+}
+
+func (s *completionSource) savedSearches(cur string) []completion {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return nil
+	}
+
+	searches, err := config.Searches(cfg)
+	if err != nil {
+		return nil
+	}
+
+	cands := make([]completion, 0, len(searches))
+	for _, ss := range searches {
+		q, _ := ss.Build(output.Now())
+		cands = append(cands, completion{Value: savedSearchPrefix + ss.Name, Description: q})
+	}
+
+	return filterCompletions(cands, cur, false)
+}
+
+func filterCompletions(cands []completion, prefix string, sorted bool) []completion {
+	lower := strings.ToLower(prefix)
+	out := cands[:0]
+
+	for _, c := range cands {
+		if strings.HasPrefix(strings.ToLower(c.Value), lower) {
+			out = append(out, c)
+		}
+	}
+
+	if sorted {
+		sort.SliceStable(out, func(i, j int) bool {
+			return strings.ToLower(out[i].Value) < strings.ToLower(out[j].Value)
+		})
+	}
+
+	return out
+}
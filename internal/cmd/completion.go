@@ -5,10 +5,16 @@ import (
 	"os"
 )
 
+// The scripts below are thin wrappers around the hidden "__complete" command
+// (see complete.go), which walks the kong model for commands, flags and enum
+// values and queries the API for collections, tags and raindrop IDs. They
+// never need updating when commands or flags change.
+
 type CompletionCmd struct {
-	Bash BashCompletionCmd `cmd:"" help:"Generate bash completion script"`
-	Zsh  ZshCompletionCmd  `cmd:"" help:"Generate zsh completion script"`
-	Fish FishCompletionCmd `cmd:"" help:"Generate fish completion script"`
+	Bash       BashCompletionCmd       `cmd:"" help:"Generate bash completion script"`
+	Zsh        ZshCompletionCmd        `cmd:"" help:"Generate zsh completion script"`
+	Fish       FishCompletionCmd       `cmd:"" help:"Generate fish completion script"`
+	Powershell PowershellCompletionCmd `cmd:"" name:"powershell" help:"Generate PowerShell completion script"`
 }
 
 type BashCompletionCmd struct{}
@@ -17,55 +23,25 @@ func (c *BashCompletionCmd) Run() error {
 	script := `_raindrop_completions() {
     local cur="${COMP_WORDS[COMP_CWORD]}"
     local prev="${COMP_WORDS[COMP_CWORD-1]}"
+    local line value
+    local -a candidates=()
 
-    # Main commands
-    local commands="add list get update delete search collections tags highlights import export open copy auth config version completion"
-
-    # Subcommands
-    local auth_cmds="setup login token status logout"
-    local config_cmds="path get set"
-    local collections_cmds="list get create update delete"
-    local tags_cmds="list rename merge delete"
-    local highlights_cmds="list add delete"
-    local completion_cmds="bash zsh fish"
-
-    case "$prev" in
-        raindrop)
-            COMPREPLY=($(compgen -W "$commands" -- "$cur"))
-            return
-            ;;
-        auth)
-            COMPREPLY=($(compgen -W "$auth_cmds" -- "$cur"))
-            return
-            ;;
-        config)
-            COMPREPLY=($(compgen -W "$config_cmds" -- "$cur"))
-            return
-            ;;
-        collections)
-            COMPREPLY=($(compgen -W "$collections_cmds" -- "$cur"))
-            return
-            ;;
-        tags)
-            COMPREPLY=($(compgen -W "$tags_cmds" -- "$cur"))
+    while IFS= read -r line; do
+        if [[ "$line" == "` + fileCompletionDirective + `" ]]; then
+            compopt -o filenames 2>/dev/null
+            COMPREPLY=($(compgen -f -- "$cur"))
             return
-            ;;
-        highlights)
-            COMPREPLY=($(compgen -W "$highlights_cmds" -- "$cur"))
-            return
-            ;;
-        completion)
-            COMPREPLY=($(compgen -W "$completion_cmds" -- "$cur"))
-            return
-            ;;
-    esac
+        fi
 
-    # Handle flags
-    if [[ "$cur" == -* ]]; then
-        local flags="--help --json --verbose --force --no-input --version"
-        COMPREPLY=($(compgen -W "$flags" -- "$cur"))
-        return
-    fi
+        value="${line%%$'\t'*}"
+        # "--flag=value": bash completes only the part after "="
+        if [[ "$prev" == "=" || "$cur" == "=" ]]; then
+            value="${value#*=}"
+        fi
+        candidates+=("$(printf '%q' "$value")")
+    done < <(raindrop __complete -- "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null)
+
+    COMPREPLY=("${candidates[@]}")
 }
 
 complete -F _raindrop_completions raindrop
@@ -83,69 +59,28 @@ func (c *ZshCompletionCmd) Run() error {
 	script := `#compdef raindrop
 
 _raindrop() {
-    local -a commands
-    commands=(
-        'add:Add a bookmark'
-        'list:List bookmarks'
-        'get:Get bookmark details'
-        'update:Update a bookmark'
-        'delete:Delete a bookmark'
-        'search:Search bookmarks'
-        'collections:Manage collections'
-        'tags:Manage tags'
-        'highlights:Manage highlights'
-        'import:Import bookmarks from HTML file'
-        'export:Export bookmarks'
-        'open:Open bookmark in browser'
-        'copy:Copy bookmark URL to clipboard'
-        'auth:Authentication and credentials'
-        'config:Manage configuration'
-        'version:Print version'
-        'completion:Generate shell completions'
-    )
-
-    _arguments -C \
-        '--help[Show help]' \
-        '--json[Output JSON]' \
-        '--verbose[Enable verbose logging]' \
-        '--force[Skip confirmations]' \
-        '--no-input[Fail instead of prompting]' \
-        '--version[Print version]' \
-        '1: :->cmd' \
-        '*::arg:->args'
-
-    case "$state" in
-        cmd)
-            _describe -t commands 'raindrop commands' commands
-            ;;
-        args)
-            case $words[1] in
-                auth)
-                    local -a auth_cmds
-                    auth_cmds=('setup:Configure OAuth credentials' 'login:OAuth login' 'token:Set test token' 'status:Show auth status' 'logout:Remove credentials')
-                    _describe -t commands 'auth commands' auth_cmds
-                    ;;
-                collections)
-                    local -a col_cmds
-                    col_cmds=('list:List collections' 'get:Get collection' 'create:Create collection' 'update:Update collection' 'delete:Delete collection')
-                    _describe -t commands 'collection commands' col_cmds
-                    ;;
-                tags)
-                    local -a tag_cmds
-                    tag_cmds=('list:List tags' 'rename:Rename tag' 'merge:Merge tags' 'delete:Delete tags')
-                    _describe -t commands 'tag commands' tag_cmds
-                    ;;
-                highlights)
-                    local -a hl_cmds
-                    hl_cmds=('list:List highlights' 'add:Add highlight' 'delete:Delete highlight')
-                    _describe -t commands 'highlight commands' hl_cmds
-                    ;;
-            esac
-            ;;
-    esac
+    local -a candidates
+    local line
+
+    for line in "${(@f)$(raindrop __complete -- "${(@)words[2,CURRENT]}" 2>/dev/null)}"; do
+        [[ -z "$line" ]] && continue
+
+        if [[ "$line" == "` + fileCompletionDirective + `" ]]; then
+            _files
+            return
+        fi
+
+        candidates+=("${${line%%$'\t'*}//:/\\:}:${line#*$'\t'}")
+    done
+
+    _describe -t values 'raindrop' candidates
 }
 
-_raindrop "$@"
+if [[ "$funcstack[1]" == "_raindrop" ]]; then
+    _raindrop "$@"
+else
+    compdef _raindrop raindrop
+fi
 `
 	fmt.Fprintln(os.Stdout, script)
 	fmt.Fprintln(os.Stderr, "# Add this to ~/.zshrc:")
@@ -159,55 +94,20 @@ type FishCompletionCmd struct{}
 func (c *FishCompletionCmd) Run() error {
 	script := `# Fish completion for raindrop
 
-# Disable file completion by default
-complete -c raindrop -f
-
-# Main commands
-complete -c raindrop -n "__fish_use_subcommand" -a "add" -d "Add a bookmark"
-complete -c raindrop -n "__fish_use_subcommand" -a "list" -d "List bookmarks"
-complete -c raindrop -n "__fish_use_subcommand" -a "get" -d "Get bookmark details"
-complete -c raindrop -n "__fish_use_subcommand" -a "update" -d "Update a bookmark"
-complete -c raindrop -n "__fish_use_subcommand" -a "delete" -d "Delete a bookmark"
-complete -c raindrop -n "__fish_use_subcommand" -a "search" -d "Search bookmarks"
-complete -c raindrop -n "__fish_use_subcommand" -a "collections" -d "Manage collections"
-complete -c raindrop -n "__fish_use_subcommand" -a "tags" -d "Manage tags"
-complete -c raindrop -n "__fish_use_subcommand" -a "highlights" -d "Manage highlights"
-complete -c raindrop -n "__fish_use_subcommand" -a "import" -d "Import bookmarks"
-complete -c raindrop -n "__fish_use_subcommand" -a "export" -d "Export bookmarks"
-complete -c raindrop -n "__fish_use_subcommand" -a "open" -d "Open in browser"
-complete -c raindrop -n "__fish_use_subcommand" -a "copy" -d "Copy URL"
-complete -c raindrop -n "__fish_use_subcommand" -a "auth" -d "Authentication"
-complete -c raindrop -n "__fish_use_subcommand" -a "config" -d "Configuration"
-complete -c raindrop -n "__fish_use_subcommand" -a "version" -d "Print version"
-complete -c raindrop -n "__fish_use_subcommand" -a "completion" -d "Shell completions"
-
-# Auth subcommands
-complete -c raindrop -n "__fish_seen_subcommand_from auth" -a "token" -d "Set test token"
-complete -c raindrop -n "__fish_seen_subcommand_from auth" -a "setup" -d "Configure OAuth credentials"
-complete -c raindrop -n "__fish_seen_subcommand_from auth" -a "login" -d "OAuth login"
-complete -c raindrop -n "__fish_seen_subcommand_from auth" -a "status" -d "Show status"
-complete -c raindrop -n "__fish_seen_subcommand_from auth" -a "logout" -d "Logout"
-
-# Collections subcommands
-complete -c raindrop -n "__fish_seen_subcommand_from collections" -a "list" -d "List"
-complete -c raindrop -n "__fish_seen_subcommand_from collections" -a "get" -d "Get"
-complete -c raindrop -n "__fish_seen_subcommand_from collections" -a "create" -d "Create"
-complete -c raindrop -n "__fish_seen_subcommand_from collections" -a "update" -d "Update"
-complete -c raindrop -n "__fish_seen_subcommand_from collections" -a "delete" -d "Delete"
-
-# Tags subcommands
-complete -c raindrop -n "__fish_seen_subcommand_from tags" -a "list" -d "List"
-complete -c raindrop -n "__fish_seen_subcommand_from tags" -a "rename" -d "Rename"
-complete -c raindrop -n "__fish_seen_subcommand_from tags" -a "merge" -d "Merge"
-complete -c raindrop -n "__fish_seen_subcommand_from tags" -a "delete" -d "Delete"
-
-# Global flags
-complete -c raindrop -l help -d "Show help"
-complete -c raindrop -l json -d "Output JSON"
-complete -c raindrop -l verbose -d "Verbose logging"
-complete -c raindrop -l force -d "Skip confirmations"
-complete -c raindrop -l no-input -d "Non-interactive mode"
-complete -c raindrop -l version -d "Print version"
+function __raindrop_complete
+    set -l tokens (commandline -opc) (commandline -ct)
+    set -l out (raindrop __complete -- $tokens[2..-1] 2>/dev/null)
+
+    if test (count $out) -eq 1; and test "$out[1]" = "` + fileCompletionDirective + `"
+        __fish_complete_path (commandline -ct)
+        return
+    end
+
+    printf '%s\n' $out
+end
+
+# Disable file completion by default; __complete asks for it when needed
+complete -c raindrop -f -a "(__raindrop_complete)"
 `
 	fmt.Fprintln(os.Stdout, script)
 	fmt.Fprintln(os.Stderr, "# Save to ~/.config/fish/completions/raindrop.fish:")
@@ -215,3 +115,40 @@ complete -c raindrop -l version -d "Print version"
 
 	return nil
 }
+
+type PowershellCompletionCmd struct{}
+
+func (c *PowershellCompletionCmd) Run() error {
+	script := `# PowerShell completion for raindrop
+Register-ArgumentCompleter -Native -CommandName raindrop -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $words = @($commandAst.CommandElements |
+        Select-Object -Skip 1 |
+        Where-Object { $_.Extent.StartOffset -lt $cursorPosition } |
+        ForEach-Object { $_.ToString() })
+    if ($wordToComplete -eq '') {
+        # Older PowerShell versions drop empty arguments to native commands
+        $words += '""'
+    }
+
+    $out = @(raindrop __complete -- @words 2>$null)
+    if ($out.Count -eq 1 -and $out[0] -eq '` + fileCompletionDirective + `') {
+        # Returning nothing makes PowerShell fall back to path completion
+        return
+    }
+
+    $out | ForEach-Object {
+        $value, $desc = $_ -split "` + "`" + `t", 2
+        if (-not $desc) { $desc = $value }
+        $type = if ($value.StartsWith('-')) { 'ParameterName' } else { 'ParameterValue' }
+        [System.Management.Automation.CompletionResult]::new($value, $value, $type, $desc)
+    }
+}
+`
+	fmt.Fprintln(os.Stdout, script)
+	fmt.Fprintln(os.Stderr, "# Add this to your PowerShell profile:")
+	fmt.Fprintln(os.Stderr, "# raindrop completion powershell | Out-String | Invoke-Expression")
+
+	return nil
+}
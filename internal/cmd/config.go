@@ -3,8 +3,13 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/dedene/raindrop-cli/internal/cache"
 	"github.com/dedene/raindrop-cli/internal/config"
+	"github.com/dedene/raindrop-cli/internal/output"
 )
 
 type ConfigCmd struct {
@@ -39,7 +44,7 @@ func (c *ConfigPathCmd) Run() error {
 }
 
 type ConfigGetCmd struct {
-	Key string `arg:"" help:"Configuration key (default_output, timezone, oauth_port)"`
+	Key string `arg:"" help:"Configuration key (default_output, default_fields, timezone, dates, hyperlinks, oauth_port, cache_ttl, searches_file, picker)"`
 }
 
 func (c *ConfigGetCmd) Run() error {
@@ -67,6 +72,33 @@ func (c *ConfigGetCmd) Run() error {
 		} else {
 			value = fmt.Sprintf("%d", cfg.OAuthPort)
 		}
+	case "dates":
+		value = cfg.Dates
+		if value == "" {
+			value = "absolute"
+		}
+	case "hyperlinks":
+		value = cfg.Hyperlinks
+		if value == "" {
+			value = "auto"
+		}
+	case "cache_ttl":
+		value = cfg.CacheTTL
+		if value == "" {
+			value = cache.DefaultTTL.String()
+		}
+	case "default_fields":
+		value = cfg.DefaultFields
+		if value == "" {
+			value = strings.Join(output.DefaultRaindropFields, ",")
+		}
+	case "searches_file":
+		value = cfg.SearchesFile
+	case "picker":
+		value = cfg.Picker
+		if value == "" {
+			value = "auto"
+		}
 	default:
 		return fmt.Errorf("unknown config key: %s", c.Key)
 	}
@@ -89,13 +121,29 @@ func (c *ConfigSetCmd) Run() error {
 
 	switch c.Key {
 	case "default_output":
-		if c.Value != "table" && c.Value != "json" {
-			return fmt.Errorf("invalid output mode: %s (must be 'table' or 'json')", c.Value)
+		if _, err := output.ParseMode(c.Value); err != nil {
+			return err
 		}
 
 		cfg.DefaultOutput = c.Value
 	case "timezone":
+		if _, err := time.LoadLocation(c.Value); err != nil {
+			return fmt.Errorf("invalid timezone: %s (use an IANA name such as Europe/Brussels, UTC or Local)", c.Value)
+		}
+
 		cfg.Timezone = c.Value
+	case "dates":
+		if _, err := output.ParseDateStyle(c.Value); err != nil {
+			return err
+		}
+
+		cfg.Dates = c.Value
+	case "hyperlinks":
+		if !slices.Contains(hyperlinkModes, c.Value) {
+			return fmt.Errorf("invalid hyperlinks mode: %s (must be auto, on or off)", c.Value)
+		}
+
+		cfg.Hyperlinks = c.Value
 	case "oauth_port":
 		var port int
 		if _, err := fmt.Sscanf(c.Value, "%d", &port); err != nil {
@@ -103,6 +151,32 @@ func (c *ConfigSetCmd) Run() error {
 		}
 
 		cfg.OAuthPort = port
+	case "cache_ttl":
+		ttl, err := time.ParseDuration(c.Value)
+		if err != nil || ttl < 0 {
+			return fmt.Errorf("invalid cache TTL: %s (e.g. 10m, 1h, or 0 to disable)", c.Value)
+		}
+
+		cfg.CacheTTL = c.Value
+	case "default_fields":
+		fields, err := output.ParseRaindropFields(c.Value)
+		if err != nil {
+			return err
+		}
+
+		cfg.DefaultFields = strings.Join(fields, ",")
+	case "searches_file":
+		if _, err := config.ReadSharedSearches(c.Value); err != nil {
+			return err
+		}
+
+		cfg.SearchesFile = c.Value
+	case "picker":
+		if !slices.Contains(pickerModes, c.Value) {
+			return fmt.Errorf("invalid picker: %s (must be auto, builtin or fzf)", c.Value)
+		}
+
+		cfg.Picker = c.Value
 	default:
 		return fmt.Errorf("unknown config key: %s", c.Key)
 	}
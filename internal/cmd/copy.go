@@ -5,31 +5,45 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"strings"
 
 	"github.com/dedene/raindrop-cli/internal/errfmt"
 )
 
 type CopyCmd struct {
-	ID int `arg:"" help:"Raindrop ID"`
+	IDs []string `arg:"" optional:"" name:"id" help:"Raindrop IDs or handles such as @3, @1..@5 (omit to pick interactively); several URLs are copied one per line"`
 }
 
-func (c *CopyCmd) Run(_ *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+func (c *CopyCmd) Run(flags *RootFlags) error {
+	ids, err := raindropIDs(flags, c.IDs)
 	if err != nil {
-		return errfmt.Format(err)
+		return err
 	}
-	defer cancel()
 
-	raindrop, err := client.GetRaindrop(ctx, c.ID)
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
+	defer cancel()
+
+	links := make([]string, 0, len(ids))
 
-	if err := copyToClipboard(raindrop.Link); err != nil {
+	for _, id := range ids {
+		raindrop, err := client.GetRaindrop(ctx, id)
+		if err != nil {
+			return errfmt.Format(err)
+		}
+
+		links = append(links, raindrop.Link)
+	}
+
+	if err := copyToClipboard(strings.Join(links, "\n")); err != nil {
 		return err
 	}
 
-	fmt.Fprintf(os.Stdout, "Copied: %s\n", raindrop.Link)
+	for _, link := range links {
+		fmt.Fprintf(os.Stdout, "Copied: %s\n", link)
+	}
 
 	return nil
 }
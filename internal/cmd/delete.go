@@ -1,43 +1,64 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 
+	"github.com/dedene/raindrop-cli/internal/api"
+	"github.com/dedene/raindrop-cli/internal/bulk"
 	"github.com/dedene/raindrop-cli/internal/errfmt"
 )
 
 type DeleteCmd struct {
-	ID        int  `arg:"" help:"Raindrop ID"`
-	Permanent bool `help:"Permanently delete (skip trash)" short:"p"`
+	IDs       []string `arg:"" optional:"" name:"id" help:"Raindrop IDs or handles such as @3, @1..@5 (omit to pick interactively)"`
+	Permanent bool     `help:"Permanently delete (skip trash)" short:"p"`
 }
 
 func (c *DeleteCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	ids, err := raindropIDs(flags, c.IDs)
 	if err != nil {
-		return errfmt.Format(err)
+		return err
 	}
-	defer cancel()
 
-	// Get raindrop first to show what we're deleting
-	raindrop, err := client.GetRaindrop(ctx, c.ID)
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
+	defer cancel()
 
 	action := "move to trash"
 	if c.Permanent {
 		action = "permanently delete"
 	}
 
-	msg := fmt.Sprintf("%s '%s' (ID: %d)?", action, truncate(raindrop.Title, 40), c.ID)
+	var msg string
+
+	if len(ids) == 1 {
+		// Get raindrop first to show what we're deleting
+		raindrop, err := client.GetRaindrop(ctx, ids[0])
+		if err != nil {
+			return errfmt.Format(err)
+		}
+
+		msg = fmt.Sprintf("%s '%s' (ID: %d)?", action, truncate(raindrop.Title, 40), ids[0])
+	} else {
+		msg = fmt.Sprintf("%s %d raindrops?", action, len(ids))
+	}
+
 	if !confirmAction(msg, flags) {
 		fmt.Fprintln(os.Stdout, "Cancelled.")
 
 		return nil
 	}
 
-	if err := client.DeleteRaindrop(ctx, c.ID, c.Permanent); err != nil {
+	if len(ids) > 1 {
+		return c.deleteAll(client, flags, ids)
+	}
+
+	if err := client.DeleteRaindrop(ctx, ids[0], c.Permanent); err != nil {
 		return errfmt.Format(err)
 	}
 
@@ -49,3 +70,33 @@ func (c *DeleteCmd) Run(flags *RootFlags) error {
 
 	return nil
 }
+
+// deleteAll deletes several raindrops, continuing past failures.
+func (c *DeleteCmd) deleteAll(client *api.Client, flags *RootFlags, ids []int) error {
+	results, runErr := bulk.Run(flags.Context(), ids, strconv.Itoa,
+		func(ctx context.Context, batch []int) ([]int, error) {
+			return batch, client.DeleteRaindrop(ctx, batch[0], c.Permanent)
+		},
+		bulkOptions(1))
+
+	// On an interrupt, report the raindrops deleted so far.
+	interrupted := errors.Is(runErr, context.Canceled)
+	if runErr != nil && !interrupted {
+		return errfmt.Format(runErr)
+	}
+
+	summary := &bulkSummary{verb: "Trashed", noun: "raindrop"}
+	if c.Permanent {
+		summary.verb = "Permanently deleted"
+	}
+
+	summary.add(results, func(key string) string { return "ID " + key })
+	summary.unprocessed = len(ids) - len(results)
+	summary.print(summaryWriter(flags))
+
+	if interrupted {
+		return runErr
+	}
+
+	return summary.err()
+}
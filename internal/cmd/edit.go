@@ -0,0 +1,381 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dedene/raindrop-cli/internal/api"
+	"github.com/dedene/raindrop-cli/internal/errfmt"
+	"github.com/dedene/raindrop-cli/internal/output"
+	"github.com/dedene/raindrop-cli/internal/tui"
+)
+
+type EditCmd struct {
+	ID string `arg:"" optional:"" help:"Raindrop ID or handle such as @3 (omit to pick interactively)"`
+}
+
+// editDoc is the editable form of a raindrop: YAML front matter with the
+// note as the Markdown body.
+type editDoc struct {
+	Title      string   `yaml:"title"`
+	Link       string   `yaml:"link"`
+	Tags       []string `yaml:"tags,flow"`
+	Collection string   `yaml:"collection"`
+	Important  bool     `yaml:"important"`
+	Excerpt    string   `yaml:"excerpt"`
+	Note       string   `yaml:"-"`
+}
+
+const frontMatterDelim = "---"
+
+func (c *EditCmd) Run(flags *RootFlags) error {
+	if flags.NoInput || !tui.IsTerminal(os.Stdout) {
+		return &ExitError{Code: ExitUsage, Err: errors.New("edit needs an interactive terminal; use update instead")}
+	}
+
+	id, err := raindropID(flags, c.ID)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(flags)
+	if err != nil {
+		return errfmt.Format(err)
+	}
+
+	raindrop, paths, err := fetchForEdit(flags.Context(), client, id)
+	if err != nil {
+		return errfmt.Format(err)
+	}
+
+	before := newEditDoc(raindrop, paths)
+
+	req, changes, err := editInEditor(flags.Context(), client, raindrop, before)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(os.Stdout, "No changes.")
+
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(flags.Context(), defaultTimeout)
+	defer cancel()
+
+	updated, err := client.UpdateRaindrop(ctx, id, req)
+	if err != nil {
+		return errfmt.Format(err)
+	}
+
+	u := raindropUpdate{ID: id, Changes: changes, Item: updated}
+
+	return newRenderer(flags).Item(u, raindropTable(flags, *updated), func(w io.Writer) {
+		output.FormatChanges(w, changes)
+		fmt.Fprintf(w, "Updated: %s (ID: %d)\n", updated.Title, updated.ID)
+	})
+}
+
+// fetchForEdit returns the raindrop and the collection paths by ID.
+func fetchForEdit(ctx context.Context, client *api.Client, id int) (*api.Raindrop, map[int]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	raindrop, err := client.GetRaindrop(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	collections, err := client.Collections(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return raindrop, api.CollectionPaths(collections), nil
+}
+
+func newEditDoc(r *api.Raindrop, paths map[int]string) editDoc {
+	doc := editDoc{
+		Title:      r.Title,
+		Link:       r.Link,
+		Tags:       r.Tags,
+		Collection: collectionLabel(r.CollectionID(), paths),
+		Important:  r.Important,
+		Excerpt:    r.Excerpt,
+		Note:       r.Note,
+	}
+	doc.normalize()
+
+	return doc
+}
+
+// normalize trims the fields and drops empty tags, so that stored
+// whitespace isn't taken for an edit.
+func (d *editDoc) normalize() {
+	d.Title = strings.TrimSpace(d.Title)
+	d.Link = strings.TrimSpace(d.Link)
+	d.Collection = strings.TrimSpace(d.Collection)
+	d.Excerpt = strings.TrimSpace(d.Excerpt)
+	d.Note = strings.TrimSpace(d.Note)
+
+	var tags []string
+
+	for _, tag := range d.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	d.Tags = tags
+}
+
+// collectionLabel returns the path of a collection ID, as accepted by
+// ResolveCollection.
+func collectionLabel(id int, paths map[int]string) string {
+	switch {
+	case id == api.SystemCollectionUnsorted:
+		return "Unsorted"
+	case id == api.SystemCollectionTrash:
+		return "Trash"
+	case paths[id] != "":
+		return paths[id]
+	}
+
+	return strconv.Itoa(id)
+}
+
+// editInEditor opens before in the editor until the result parses, and
+// returns the update for the changed fields. An emptied file cancels.
+func editInEditor(ctx context.Context, client *api.Client, r *api.Raindrop, before editDoc) (*api.UpdateRaindropRequest, []output.FieldChange, error) {
+	f, err := os.CreateTemp("", fmt.Sprintf("raindrop-%d-*.md", r.ID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("create temp file: %w", err)
+	}
+
+	path := f.Name()
+	_ = f.Close()
+
+	defer os.Remove(path)
+
+	content := before.render()
+
+	for {
+		edited, err := runEditor(path, content)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if strings.TrimSpace(stripEditComments(edited)) == "" {
+			return nil, nil, &ExitError{Code: ExitGeneric, Err: errors.New("edit cancelled: the file was emptied")}
+		}
+
+		after, err := parseEditDoc(edited)
+		if err == nil {
+			var (
+				req     *api.UpdateRaindropRequest
+				changes []output.FieldChange
+			)
+
+			if req, changes, err = editChanges(ctx, client, before, after); err == nil {
+				return req, changes, nil
+			}
+		}
+
+		content = withEditError(edited, err)
+	}
+}
+
+// runEditor writes content to path, opens it in $VISUAL or $EDITOR and
+// returns the saved content.
+func runEditor(path, content string) (string, error) {
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		return "", fmt.Errorf("write temp file: %w", err)
+	}
+
+	args := strings.Fields(editorCommand())
+
+	cmd := exec.Command(args[0], append(args[1:], path)...) //nolint:gosec // editor chosen by the user
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("run editor %s: %w", args[0], err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read temp file: %w", err)
+	}
+
+	return string(b), nil
+}
+
+// editorCommand returns $VISUAL, then $EDITOR, then a platform default.
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+			return v
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+
+	return "vi"
+}
+
+func (d editDoc) render() string {
+	var buf bytes.Buffer
+
+	buf.WriteString(frontMatterDelim + "\n")
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	_ = enc.Encode(d)
+	_ = enc.Close()
+
+	buf.WriteString(frontMatterDelim + "\n\n")
+
+	if d.Note != "" {
+		buf.WriteString(d.Note + "\n")
+	}
+
+	return buf.String()
+}
+
+// parseEditDoc parses an edited file. Comment lines above the front matter
+// (see withEditError) are ignored.
+func parseEditDoc(content string) (editDoc, error) {
+	content = strings.ReplaceAll(stripEditComments(content), "\r\n", "\n")
+
+	rest, ok := strings.CutPrefix(content, frontMatterDelim+"\n")
+	if !ok {
+		return editDoc{}, errors.New("the file must start with a --- line")
+	}
+
+	front, body, ok := strings.Cut("\n"+rest, "\n"+frontMatterDelim+"\n")
+	if !ok {
+		if front, ok = strings.CutSuffix(strings.TrimRight("\n"+rest, "\n"), "\n"+frontMatterDelim); !ok {
+			return editDoc{}, errors.New("the front matter must end with a --- line")
+		}
+	}
+
+	var doc editDoc
+
+	dec := yaml.NewDecoder(strings.NewReader(front))
+	dec.KnownFields(true)
+
+	if err := dec.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return editDoc{}, fmt.Errorf("front matter: %w", err)
+	}
+
+	doc.Note = body
+	doc.normalize()
+
+	if doc.Link == "" {
+		return editDoc{}, errors.New("link must not be empty")
+	}
+
+	if doc.Collection == "" {
+		return editDoc{}, errors.New("collection must not be empty")
+	}
+
+	return doc, nil
+}
+
+// editChanges returns the update for the fields that differ between
+// before and after, and the changes to show.
+func editChanges(ctx context.Context, client *api.Client, before, after editDoc) (*api.UpdateRaindropRequest, []output.FieldChange, error) {
+	req := &api.UpdateRaindropRequest{}
+
+	var changes []output.FieldChange
+
+	change := func(field string, b, a any) {
+		changes = append(changes, output.FieldChange{Field: field, Before: b, After: a})
+	}
+
+	if after.Title != before.Title {
+		req.Title = &after.Title
+		change("title", before.Title, after.Title)
+	}
+
+	if after.Link != before.Link {
+		req.Link = &after.Link
+		change("link", before.Link, after.Link)
+	}
+
+	if !slices.Equal(after.Tags, before.Tags) {
+		req.SetTags(after.Tags)
+		change("tags", before.Tags, *req.Tags)
+	}
+
+	if after.Collection != before.Collection {
+		ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+
+		id, err := client.ResolveCollection(ctx, after.Collection)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		req.SetCollection(id)
+		change("collection", before.Collection, after.Collection)
+	}
+
+	if after.Important != before.Important {
+		req.Important = &after.Important
+		change("important", before.Important, after.Important)
+	}
+
+	if after.Excerpt != before.Excerpt {
+		req.Excerpt = &after.Excerpt
+		change("excerpt", before.Excerpt, after.Excerpt)
+	}
+
+	if after.Note != before.Note {
+		req.Note = &after.Note
+		change("note", before.Note, after.Note)
+	}
+
+	return req, changes, nil
+}
+
+// withEditError puts err as comment lines above the edited content.
+func withEditError(content string, err error) string {
+	var b strings.Builder
+
+	for _, line := range strings.Split(err.Error(), "\n") {
+		fmt.Fprintf(&b, "# error: %s\n", line)
+	}
+
+	b.WriteString("# Fix the file and save it, or empty it to cancel. These lines are ignored.\n")
+	b.WriteString(stripEditComments(content))
+
+	return b.String()
+}
+
+// stripEditComments removes comment and blank lines above the front matter.
+func stripEditComments(content string) string {
+	for content != "" {
+		line, rest, _ := strings.Cut(content, "\n")
+		if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+
+		content = rest
+	}
+
+	return content
+}
@@ -9,8 +9,13 @@ const (
 	ExitAuth      = 3
 	ExitNotFound  = 4
 	ExitRateLimit = 5
+	// ExitInterrupted follows the shell convention of 128 + SIGINT.
+	ExitInterrupted = 130
 )
 
+// errInterrupted reports a command stopped by SIGINT or SIGTERM.
+var errInterrupted = errors.New("interrupted")
+
 type ExitError struct {
 	Code int
 	Err  error
@@ -1,27 +1,68 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/dedene/raindrop-cli/internal/errfmt"
+	"github.com/dedene/raindrop-cli/internal/output"
 )
 
 type ExportCmd struct {
-	Collection string `arg:"" optional:"" help:"Collection name/ID (default: all)" default:"0"`
+	Collection string `arg:"" optional:"" help:"Collection name, path or ID (default: all)" default:"0"`
 	Format     string `required:"" help:"Export format (csv, html, zip)" enum:"csv,html,zip" short:"f"`
-	Output     string `help:"Output file (default: stdout)" short:"o"`
+	// --output and -o select the output format on every command; the file
+	// flag was --output before that.
+	File string `help:"Output file (default: stdout)" name:"output-file" aliases:"file" short:"O" type:"path"`
+}
+
+// exportFileHint returns a usage error when export's former --output/-o flag
+// was given a file name, which now fails as an output format.
+func exportFileHint(args []string) error {
+	if !slices.Contains(args, "export") {
+		return nil
+	}
+
+	for i, arg := range args {
+		var value string
+
+		switch {
+		case arg == "-o" || arg == "--output":
+			if i+1 < len(args) {
+				value = args[i+1]
+			}
+		case strings.HasPrefix(arg, "--output="):
+			value = strings.TrimPrefix(arg, "--output=")
+		case strings.HasPrefix(arg, "-o") && len(arg) > 2:
+			value = arg[2:]
+		}
+
+		if value == "" {
+			continue
+		}
+
+		if _, err := output.ParseMode(value); err != nil {
+			return &ExitError{Code: ExitUsage, Err: fmt.Errorf("--output selects the output format; write the export to a file with --output-file %s (or -O)", value)}
+		}
+	}
+
+	return nil
 }
 
 func (c *ExportCmd) Run(flags *RootFlags) error {
 	// Zip format requires output file (binary data)
-	if c.Format == "zip" && c.Output == "" {
-		return fmt.Errorf("zip format requires --output file (binary data cannot be written to stdout)")
+	if c.Format == "zip" && c.File == "" {
+		return fmt.Errorf("zip format requires --output-file (binary data cannot be written to stdout)")
 	}
 
-	client, ctx, cancel, err := getClientWithContext()
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
@@ -35,6 +76,11 @@ func (c *ExportCmd) Run(flags *RootFlags) error {
 	// Build export URL
 	exportURL := fmt.Sprintf("https://api.raindrop.io/rest/v1/raindrops/%d/export.%s", collectionID, c.Format)
 
+	// A large export may take long to download; it only fails when no data
+	// arrives for defaultTimeout.
+	ctx, stall := stallContext(ctx, defaultTimeout)
+	defer stall.Stop()
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, exportURL, nil)
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
@@ -49,7 +95,7 @@ func (c *ExportCmd) Run(flags *RootFlags) error {
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("do request: %w", err)
+		return fmt.Errorf("do request: %w", stalled(ctx, err))
 	}
 	defer resp.Body.Close()
 
@@ -62,8 +108,8 @@ func (c *ExportCmd) Run(flags *RootFlags) error {
 	// Determine output destination
 	var out io.Writer = os.Stdout
 
-	if c.Output != "" {
-		f, createErr := os.Create(c.Output)
+	if c.File != "" {
+		f, createErr := os.Create(c.File)
 		if createErr != nil {
 			return fmt.Errorf("create output file: %w", createErr)
 		}
@@ -72,15 +118,77 @@ func (c *ExportCmd) Run(flags *RootFlags) error {
 		out = f
 	}
 
-	// Copy response to output
-	written, err := io.Copy(out, resp.Body)
+	// Copy response to output, with progress unless it would mix with the
+	// export on the terminal. The size is unknown without Content-Length.
+	var progress *output.Progress
+
+	if c.File != "" || !output.IsTerminal(os.Stdout) {
+		progress = newProgress(flags, "Exporting", output.UnitBytes, resp.ContentLength)
+	}
+
+	written, err := io.Copy(out, stall.Reader(progress.Reader(resp.Body)))
+	progress.Done()
+
 	if err != nil {
-		return fmt.Errorf("write output: %w", err)
+		// Don't leave an incomplete export behind.
+		if c.File != "" {
+			_ = os.Remove(c.File)
+		}
+
+		return fmt.Errorf("write output: %w", stalled(ctx, err))
 	}
 
-	if c.Output != "" {
-		fmt.Fprintf(os.Stderr, "Exported to %s (%d bytes)\n", c.Output, written)
+	if c.File != "" {
+		fmt.Fprintf(os.Stderr, "Exported to %s (%d bytes)\n", c.File, written)
 	}
 
 	return nil
 }
+
+// stallTimer cancels a context when no data was read for its timeout.
+type stallTimer struct {
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+// stallContext returns a context that is cancelled, with
+// context.DeadlineExceeded as the cause, once the timer runs out. Reads
+// through Reader restart it.
+func stallContext(ctx context.Context, timeout time.Duration) (context.Context, *stallTimer) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	t := &stallTimer{timeout: timeout}
+	t.timer = time.AfterFunc(timeout, func() { cancel(context.DeadlineExceeded) })
+
+	return ctx, t
+}
+
+// Stop releases the timer.
+func (t *stallTimer) Stop() {
+	t.timer.Stop()
+}
+
+// Reader returns r restarting the timer on each read.
+func (t *stallTimer) Reader(r io.Reader) io.Reader {
+	return readerFunc(func(b []byte) (int, error) {
+		n, err := r.Read(b)
+		t.timer.Reset(t.timeout)
+
+		return n, err //nolint:wrapcheck // io.Reader contract
+	})
+}
+
+type readerFunc func([]byte) (int, error)
+
+func (f readerFunc) Read(b []byte) (int, error) {
+	return f(b)
+}
+
+// stalled reports a request stopped by its stall timer as
+// context.DeadlineExceeded, never as an interrupt.
+func stalled(ctx context.Context, err error) error {
+	if ctx.Err() != nil && errors.Is(context.Cause(ctx), context.DeadlineExceeded) {
+		return fmt.Errorf("no data for %s: %w", defaultTimeout, context.DeadlineExceeded)
+	}
+
+	return err
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/dedene/raindrop-cli/internal/api"
+	"github.com/dedene/raindrop-cli/internal/docs"
+)
+
+type GenDocsCmd struct {
+	Format string `help:"Output format" enum:"man,markdown" default:"markdown" short:"f"`
+	Dir    string `help:"Output directory" default:"docs" type:"path"`
+}
+
+// commandExamples are shown in generated docs, keyed by command path.
+var commandExamples = map[string][]string{
+	"add": {
+		"raindrop add https://example.com",
+		`raindrop add https://example.com --collection Work/Reading --tags "reference,docs"`,
+		"cat urls.txt | raindrop add - --collection Unsorted",
+	},
+	"list": {
+		"raindrop list",
+		"raindrop list Work --all",
+		"raindrop list --favorites --sort title",
+	},
+	"get":    {"raindrop get 12345", "raindrop get 12345 --json"},
+	"update": {`raindrop update 12345 --title "New Title" --tags "go,cli"`, "raindrop update 12345 --favorite", "raindrop update @1..@5 --add-tag go --remove-tag draft"},
+	"edit":   {"raindrop edit 12345", "VISUAL=nano raindrop edit @1"},
+	"delete": {"raindrop delete 12345", "raindrop delete 12345 --permanent --force"},
+	"search": {`raindrop search "golang"`, "raindrop search --tag programming --type article --after 2024-01-01"},
+	"collections list": {
+		"raindrop collections list",
+		"raindrop collections list --flat --json",
+	},
+	"collections create": {"raindrop collections create Go --parent Work/Projects"},
+	"tags merge":         {`raindrop tags merge "golang,go-lang" --into go`},
+	"highlights add":     {`raindrop highlights add 12345 "Important passage" --color blue`},
+	"export":             {"raindrop export Work --format html --file work.html"},
+	"import":             {"raindrop import bookmarks.html"},
+	"completion bash":    {`eval "$(raindrop completion bash)"`},
+}
+
+// exitCodes documents the process exit statuses.
+var exitCodes = []docs.ExitCode{
+	{Code: api.ExitSuccess, Description: "Success"},
+	{Code: api.ExitError, Description: "General error"},
+	{Code: api.ExitUsage, Description: "Invalid usage, e.g. unknown flag or ambiguous collection name"},
+	{Code: api.ExitAuth, Description: "Authentication failed or missing"},
+	{Code: api.ExitNotFound, Description: "Resource not found"},
+	{Code: api.ExitRateLimit, Description: "Rate limit exceeded"},
+	{Code: ExitInterrupted, Description: "Interrupted by Ctrl-C (SIGINT) or SIGTERM"},
+}
+
+func (c *GenDocsCmd) Run(kctx *kong.Context) error {
+	files, err := docs.Generate(kctx.Model, docs.Format(c.Format), c.Dir, docs.Options{
+		Version:   VersionString(),
+		Date:      docsDate(),
+		Examples:  commandExamples,
+		ExitCodes: exitCodes,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %d file(s) to %s\n", len(files), c.Dir)
+
+	return nil
+}
+
+// docsDate honors SOURCE_DATE_EPOCH for reproducible builds.
+func docsDate() time.Time {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if secs, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			return time.Unix(secs, 0).UTC()
+		}
+	}
+
+	return time.Now().UTC()
+}
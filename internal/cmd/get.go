@@ -1,33 +1,36 @@
 package cmd
 
 import (
-	"os"
+	"io"
 
 	"github.com/dedene/raindrop-cli/internal/errfmt"
 	"github.com/dedene/raindrop-cli/internal/output"
 )
 
 type GetCmd struct {
-	ID int `arg:"" help:"Raindrop ID"`
+	ID string `arg:"" optional:"" help:"Raindrop ID or handle such as @3 (omit to pick interactively)"`
 }
 
 func (c *GetCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	id, err := raindropID(flags, c.ID)
 	if err != nil {
-		return errfmt.Format(err)
+		return err
 	}
-	defer cancel()
 
-	raindrop, err := client.GetRaindrop(ctx, c.ID)
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
+	defer cancel()
 
-	if flags.JSON {
-		return output.WriteJSON(os.Stdout, raindrop)
+	raindrop, err := client.GetRaindrop(ctx, id)
+	if err != nil {
+		return errfmt.Format(err)
 	}
 
-	output.FormatRaindropDetail(os.Stdout, raindrop)
+	table := raindropTable(flags, *raindrop)
 
-	return nil
+	return newRenderer(flags).Item(raindrop, table, func(w io.Writer) {
+		output.FormatRaindropDetail(w, raindrop)
+	})
 }
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dedene/raindrop-cli/internal/auth"
+	"github.com/dedene/raindrop-cli/internal/state"
+)
+
+// handlePrefix starts a reference into the last listing, as in "open @3".
+const handlePrefix = "@"
+
+// errNoListing is returned for handles when no listing was saved.
+var errNoListing = errors.New("no recent listing in this terminal session; run list or search first")
+
+// resolveIDs turns ID arguments into raindrop IDs. Besides numeric IDs,
+// arguments may be handles into the last list or search of this terminal
+// session: @N (1-based row), @N..@M or @N..M (inclusive range) and @last.
+func resolveIDs(args []string) ([]int, error) {
+	var (
+		ids     []int
+		handles *state.Handles
+	)
+
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, handlePrefix) {
+			id, err := strconv.Atoi(arg)
+			if err != nil || id <= 0 {
+				return nil, &ExitError{Code: ExitUsage, Err: fmt.Errorf("invalid raindrop ID: %s (use a number, @N, @N..@M or @last)", arg)}
+			}
+
+			ids = append(ids, id)
+
+			continue
+		}
+
+		if handles == nil {
+			h, ok, err := state.LoadHandles(auth.AccountKey())
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				return nil, &ExitError{Code: ExitUsage, Err: errNoListing}
+			}
+
+			handles = &h
+		}
+
+		first, last, err := parseHandle(arg, len(handles.IDs))
+		if err != nil {
+			return nil, &ExitError{Code: ExitUsage, Err: err}
+		}
+
+		ids = append(ids, handles.IDs[first-1:last]...)
+	}
+
+	return ids, nil
+}
+
+// parseHandle returns the 1-based row range of a handle into a listing of
+// n rows.
+func parseHandle(arg string, n int) (int, int, error) {
+	from, to, isRange := strings.Cut(strings.TrimPrefix(arg, handlePrefix), "..")
+	to = strings.TrimPrefix(to, handlePrefix)
+
+	first, err := handleRow(from, n)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid handle %s: %w", arg, err)
+	}
+
+	last := first
+
+	if isRange {
+		if last, err = handleRow(to, n); err != nil {
+			return 0, 0, fmt.Errorf("invalid handle %s: %w", arg, err)
+		}
+	}
+
+	if last < first {
+		return 0, 0, fmt.Errorf("invalid handle %s: range is reversed", arg)
+	}
+
+	return first, last, nil
+}
+
+func handleRow(s string, n int) (int, error) {
+	if s == "last" {
+		s = strconv.Itoa(n)
+	}
+
+	row, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.New("use @N, @N..@M or @last")
+	}
+
+	if row < 1 || row > n {
+		return 0, fmt.Errorf("out of range; the last listing has %d result(s)", n)
+	}
+
+	return row, nil
+}
+
+// saveHandles records the IDs of a listing for later @N references. Failing
+// to save only makes handles unavailable, so errors are ignored.
+func saveHandles(ids []int) {
+	_ = state.SaveHandles(auth.AccountKey(), ids)
+}
@@ -11,7 +11,8 @@ import (
 
 	"github.com/alecthomas/kong"
 	"github.com/muesli/termenv"
-	"golang.org/x/term"
+
+	"github.com/dedene/raindrop-cli/internal/output"
 )
 
 const (
@@ -90,7 +91,7 @@ func helpColorMode(args []string) string {
 		return strings.ToLower(strings.TrimSpace(v))
 	}
 
-	if slices.Contains(args, "--json") {
+	if slices.Contains(args, "--json") || structuredOutputArg(args) {
 		return colorNever
 	}
 
@@ -176,17 +177,25 @@ func colorizeCommandLine(line string, cmdName, dim func(string) string) string {
 }
 
 func guessColumns(w io.Writer) int {
-	if cols := os.Getenv("COLUMNS"); cols != "" {
-		if n, err := strconv.Atoi(cols); err == nil {
-			return n
-		}
+	if width := output.TerminalWidth(w); width > 0 {
+		return width
 	}
 
-	if f, ok := w.(*os.File); ok {
-		if width, _, err := term.GetSize(int(f.Fd())); err == nil && width > 0 {
-			return width
+	return 80
+}
+
+// structuredOutputArg reports whether args select a non-table output mode.
+func structuredOutputArg(args []string) bool {
+	for i, a := range args {
+		value, ok := strings.CutPrefix(a, "--output=")
+		if !ok && (a == "--output" || a == "-o") && i+1 < len(args) {
+			value, ok = args[i+1], true
+		}
+
+		if ok && value != "table" {
+			return true
 		}
 	}
 
-	return 80
+	return false
 }
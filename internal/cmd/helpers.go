@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 	"os"
@@ -9,28 +8,88 @@ import (
 	"time"
 
 	"github.com/dedene/raindrop-cli/internal/api"
+	"github.com/dedene/raindrop-cli/internal/cache"
+	"github.com/dedene/raindrop-cli/internal/config"
+	"github.com/dedene/raindrop-cli/internal/output"
 )
 
-// defaultTimeout for API calls.
+// defaultTimeout bounds each API call.
 const defaultTimeout = 30 * time.Second
 
-// getClient creates an authenticated API client.
-func getClient() (*api.Client, error) {
-	return api.NewClientFromAuth()
+// getClient creates an authenticated API client backed by the collections
+// cache, with defaultTimeout per request.
+func getClient(flags *RootFlags) (*api.Client, error) {
+	client, err := api.NewClientFromAuth()
+	if err != nil {
+		return nil, err
+	}
+
+	client.SetRequestTimeout(defaultTimeout)
+
+	if store := collectionsCache(); store != nil {
+		if flags.Refresh {
+			_ = store.Invalidate()
+		}
+
+		client.SetCollectionStore(store)
+	}
+
+	return client, nil
+}
+
+// collectionsCache returns the on-disk collections cache using the configured
+// TTL, or nil if the cache directory can't be resolved.
+func collectionsCache() *cache.Collections {
+	ttl := cache.DefaultTTL
+
+	if cfg, err := config.ReadConfig(); err == nil && cfg.CacheTTL != "" {
+		if d, parseErr := time.ParseDuration(cfg.CacheTTL); parseErr == nil {
+			ttl = d
+		}
+	}
+
+	store, err := cache.NewCollections(ttl)
+	if err != nil {
+		return nil
+	}
+
+	return store
 }
 
-// getClientWithContext creates client and context with timeout.
-func getClientWithContext() (*api.Client, context.Context, context.CancelFunc, error) {
-	client, err := getClient()
+// getClientWithContext creates client and a context for the whole
+// operation, derived from the root context so that an interrupt cancels it.
+// Requests are bounded one by one, so long operations such as following
+// every page don't run out of time.
+func getClientWithContext(flags *RootFlags) (*api.Client, context.Context, context.CancelFunc, error) {
+	client, err := getClient(flags)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := context.WithCancel(flags.Context())
 
 	return client, ctx, cancel, nil
 }
 
+// newRenderer returns a stdout renderer for the selected output mode,
+// template or jq filter.
+func newRenderer(flags *RootFlags) *output.Renderer {
+	return &output.Renderer{
+		W:        os.Stdout,
+		Mode:     flags.OutputMode(),
+		Template: flags.template,
+		JQ:       flags.jq,
+	}
+}
+
+// newProgress starts progress on stderr. The live bar is drawn in the human
+// view on a terminal without --no-input; otherwise plain lines are printed.
+func newProgress(flags *RootFlags, label, unit string, total int64) *output.Progress {
+	live := newRenderer(flags).Human() && !flags.NoInput && output.IsTerminal(os.Stderr)
+
+	return output.NewProgress(os.Stderr, label, unit, total, live)
+}
+
 // confirmAction prompts for confirmation unless --force or --no-input is set.
 func confirmAction(msg string, flags *RootFlags) bool {
 	if flags.Force {
@@ -54,34 +113,7 @@ func confirmAction(msg string, flags *RootFlags) bool {
 	return response == "y" || response == "yes"
 }
 
-// readURLsFromStdin reads newline-separated URLs from stdin.
-func readURLsFromStdin() ([]string, error) {
-	var urls []string
-
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line != "" {
-			urls = append(urls, line)
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("read stdin: %w", err)
-	}
-
-	return urls, nil
-}
-
-// truncate shortens a string to maxLen with ellipsis.
+// truncate shortens a string to maxLen terminal cells with ellipsis.
 func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-
-	if maxLen <= 3 {
-		return s[:maxLen]
-	}
-
-	return s[:maxLen-3] + "..."
+	return output.Truncate(s, maxLen)
 }
@@ -16,23 +16,28 @@ type HighlightsCmd struct {
 }
 
 type HighlightsListCmd struct {
-	ID int `arg:"" help:"Raindrop ID"`
+	ID string `arg:"" optional:"" help:"Raindrop ID or handle such as @3 (omit to pick interactively)"`
 }
 
 func (c *HighlightsListCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	id, err := raindropID(flags, c.ID)
+	if err != nil {
+		return err
+	}
+
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
 	defer cancel()
 
-	raindrop, err := client.GetRaindrop(ctx, c.ID)
+	raindrop, err := client.GetRaindrop(ctx, id)
 	if err != nil {
 		return errfmt.Format(err)
 	}
 
-	if flags.JSON {
-		return output.WriteJSON(os.Stdout, raindrop.Highlights)
+	if r := newRenderer(flags); !r.Human() {
+		return r.List(raindrop.Highlights, output.HighlightTable(raindrop.Highlights))
 	}
 
 	if len(raindrop.Highlights) == 0 {
@@ -41,18 +46,7 @@ func (c *HighlightsListCmd) Run(flags *RootFlags) error {
 		return nil
 	}
 
-	for i, h := range raindrop.Highlights {
-		fmt.Fprintf(os.Stdout, "%s %d. %s\n", output.StyleBold("Highlight"), i+1, h.Text)
-
-		if h.Note != "" {
-			fmt.Fprintf(os.Stdout, "   %s %s\n", output.StyleFaint("Note:"), h.Note)
-		}
-
-		fmt.Fprintf(os.Stdout, "   %s %s  %s %s\n",
-			output.StyleFaint("Color:"), h.Color,
-			output.StyleFaint("ID:"), h.ID)
-		fmt.Fprintln(os.Stdout)
-	}
+	output.FormatHighlights(os.Stdout, raindrop.Highlights)
 
 	fmt.Fprintf(os.Stdout, "%d highlight(s)\n", len(raindrop.Highlights))
 
@@ -60,21 +54,26 @@ func (c *HighlightsListCmd) Run(flags *RootFlags) error {
 }
 
 type HighlightsAddCmd struct {
-	RaindropID int    `arg:"" help:"Raindrop ID"`
+	RaindropID string `arg:"" help:"Raindrop ID or handle such as @3"`
 	Text       string `arg:"" help:"Highlight text"`
 	Note       string `help:"Annotation" short:"n"`
 	Color      string `help:"Color" enum:"yellow,blue,red,green,purple" default:"yellow" short:"c"`
 }
 
 func (c *HighlightsAddCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	id, err := raindropID(flags, c.RaindropID)
+	if err != nil {
+		return err
+	}
+
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
 	defer cancel()
 
 	// Get current raindrop to append highlight
-	raindrop, err := client.GetRaindrop(ctx, c.RaindropID)
+	raindrop, err := client.GetRaindrop(ctx, id)
 	if err != nil {
 		return errfmt.Format(err)
 	}
@@ -103,12 +102,12 @@ func (c *HighlightsAddCmd) Run(flags *RootFlags) error {
 		Item api.Raindrop `json:"item"`
 	}
 
-	if err := client.Put(ctx, fmt.Sprintf("/raindrop/%d", c.RaindropID), &req, &resp); err != nil {
+	if err := client.Put(ctx, fmt.Sprintf("/raindrop/%d", id), &req, &resp); err != nil {
 		return errfmt.Format(err)
 	}
 
-	if flags.JSON {
-		return output.WriteJSON(os.Stdout, resp.Item.Highlights)
+	if r := newRenderer(flags); !r.Human() {
+		return r.List(resp.Item.Highlights, output.HighlightTable(resp.Item.Highlights))
 	}
 
 	fmt.Fprintf(os.Stdout, "Added highlight to '%s'\n", resp.Item.Title)
@@ -117,19 +116,24 @@ func (c *HighlightsAddCmd) Run(flags *RootFlags) error {
 }
 
 type HighlightsDeleteCmd struct {
-	RaindropID  int    `arg:"" help:"Raindrop ID"`
+	RaindropID  string `arg:"" help:"Raindrop ID or handle such as @3"`
 	HighlightID string `arg:"" help:"Highlight ID"`
 }
 
 func (c *HighlightsDeleteCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	id, err := raindropID(flags, c.RaindropID)
+	if err != nil {
+		return err
+	}
+
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
 	defer cancel()
 
 	// Get current raindrop
-	raindrop, err := client.GetRaindrop(ctx, c.RaindropID)
+	raindrop, err := client.GetRaindrop(ctx, id)
 	if err != nil {
 		return errfmt.Format(err)
 	}
@@ -163,7 +167,7 @@ func (c *HighlightsDeleteCmd) Run(flags *RootFlags) error {
 		Item api.Raindrop `json:"item"`
 	}
 
-	if err := client.Put(ctx, fmt.Sprintf("/raindrop/%d", c.RaindropID), &req, &resp); err != nil {
+	if err := client.Put(ctx, fmt.Sprintf("/raindrop/%d", id), &req, &resp); err != nil {
 		return errfmt.Format(err)
 	}
 
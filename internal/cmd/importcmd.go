@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -17,7 +18,7 @@ type ImportCmd struct {
 }
 
 func (c *ImportCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
@@ -46,6 +47,9 @@ func (c *ImportCmd) Run(flags *RootFlags) error {
 		return fmt.Errorf("close writer: %w", closeErr)
 	}
 
+	ctx, cancelRequest := context.WithTimeout(ctx, defaultTimeout)
+	defer cancelRequest()
+
 	// Make request using raw HTTP since we need multipart
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.raindrop.io/rest/v1/import/file", body)
 	if err != nil {
@@ -1,120 +1,89 @@
 package cmd
 
 import (
-	"fmt"
-	"os"
-	"strings"
-
 	"github.com/dedene/raindrop-cli/internal/api"
 	"github.com/dedene/raindrop-cli/internal/errfmt"
 	"github.com/dedene/raindrop-cli/internal/output"
+	"github.com/dedene/raindrop-cli/internal/query"
 )
 
 type ListCmd struct {
-	Collection string `arg:"" optional:"" help:"Collection name/ID (default: all)" default:"0"`
+	Collection string `arg:"" optional:"" help:"Collection name, path or ID (default: all), or @name to run a saved search" default:"0"`
 	Favorites  bool   `help:"Only favorites" short:"f"`
 	Broken     bool   `help:"Only broken links"`
 	Type       string `help:"Filter by type (link|article|image|video|document|audio)" short:"t"`
 	Tag        string `help:"Filter by tag"`
 	Search     string `help:"Search query" short:"s"`
 	Sort       string `help:"Sort order" default:"-created" enum:"created,-created,title,-title,domain,-domain,score"`
-	All        bool   `help:"Fetch all pages (default: first 50)" short:"a"`
+	Fields     string `help:"Comma-separated fields to show (e.g. id,title,tags,collection)"`
+	PageFlags  `embed:""`
+	MatchFlags `embed:""`
 }
 
 func (c *ListCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
-	if err != nil {
-		return errfmt.Format(err)
-	}
-	defer cancel()
+	filter := c.filter()
+	collection, sort, fields := c.Collection, c.Sort, c.Fields
 
-	collectionID, err := client.ResolveCollection(ctx, c.Collection)
-	if err != nil {
-		return errfmt.Format(err)
-	}
-
-	// Build search query from filters
-	search := c.buildSearch()
-
-	opts := api.ListOptions{
-		Search:  search,
-		Sort:    c.Sort,
-		PerPage: 50,
-	}
-
-	var allItems []api.Raindrop
-
-	page := 0
-
-	for {
-		opts.Page = page
-
-		resp, err := client.ListRaindrops(ctx, collectionID, opts)
+	if name, ok := savedSearchRef(c.Collection); ok {
+		saved, err := loadSavedSearch(name)
 		if err != nil {
-			return errfmt.Format(err)
+			return err
 		}
 
-		allItems = append(allItems, resp.Items...)
+		filter = saved.Merge(filter)
+		collection, sort, fields = applySavedSearch(flags, saved.SavedSearch, "0", "", fields)
 
-		// Stop if not fetching all or no more items
-		if !c.All || len(allItems) >= resp.Count {
-			break
+		// A non-default --sort wins over the saved one.
+		if sort == "" || c.Sort != "-created" {
+			sort = c.Sort
 		}
-
-		page++
 	}
 
-	if flags.JSON {
-		return output.WriteJSON(os.Stdout, allItems)
+	// Build search query from filters
+	search, err := filter.Build(output.Now())
+	if err != nil {
+		return &ExitError{Code: ExitUsage, Err: err}
 	}
 
-	if len(allItems) == 0 {
-		fmt.Fprintln(os.Stdout, "No raindrops found.")
-
-		return nil
+	if err := c.PageFlags.validate(); err != nil {
+		return err
 	}
 
-	tw := output.NewTableWriter(os.Stdout, output.RaindropTableHeaders()...)
-	for _, r := range allItems {
-		tw.AddRow(output.FormatRaindropRow(&r, flags.HyperlinkMode())...)
+	client, ctx, cancel, err := getClientWithContext(flags)
+	if err != nil {
+		return errfmt.Format(err)
 	}
+	defer cancel()
 
-	tw.Render()
-	fmt.Fprintf(os.Stdout, "\n%d raindrop(s)\n", len(allItems))
-
-	return nil
-}
-
-func (c *ListCmd) buildSearch() string {
-	var parts []string
-
-	if c.Search != "" {
-		parts = append(parts, c.Search)
+	collectionID, err := client.ResolveCollection(ctx, collection)
+	if err != nil {
+		return errfmt.Format(err)
 	}
 
-	if c.Tag != "" {
-		parts = append(parts, "#"+c.Tag)
+	opts := api.ListOptions{
+		Search: search,
+		Sort:   sort,
 	}
 
-	if c.Type != "" {
-		parts = append(parts, "type:"+c.Type)
+	view, err := newRaindropView(ctx, client, flags, fields)
+	if err != nil {
+		return err
 	}
 
-	if c.Favorites {
-		parts = append(parts, "important:true")
-	}
+	return listRaindrops(ctx, client, flags, view, collectionID, opts, c.PageFlags, c.MatchFlags, "No raindrops found.", "raindrop")
+}
 
-	if c.Broken {
-		parts = append(parts, "broken:true")
+func (c *ListCmd) filter() query.Filter {
+	filter := query.Filter{
+		Text:      c.Search,
+		Type:      c.Type,
+		Favorites: c.Favorites,
+		Broken:    c.Broken,
 	}
 
-	if len(parts) == 0 {
-		return ""
+	if c.Tag != "" {
+		filter.Tags = []string{c.Tag}
 	}
 
-	return joinSearchParts(parts)
-}
-
-func joinSearchParts(parts []string) string {
-	return strings.Join(parts, " ")
+	return filter
 }
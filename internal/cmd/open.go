@@ -9,22 +9,33 @@ import (
 )
 
 type OpenCmd struct {
-	ID int `arg:"" help:"Raindrop ID"`
+	IDs []string `arg:"" optional:"" name:"id" help:"Raindrop IDs or handles such as @3, @1..@5 (omit to pick interactively)"`
 }
 
-func (c *OpenCmd) Run(_ *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+func (c *OpenCmd) Run(flags *RootFlags) error {
+	ids, err := raindropIDs(flags, c.IDs)
 	if err != nil {
-		return errfmt.Format(err)
+		return err
 	}
-	defer cancel()
 
-	raindrop, err := client.GetRaindrop(ctx, c.ID)
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
+	defer cancel()
+
+	for _, id := range ids {
+		raindrop, err := client.GetRaindrop(ctx, id)
+		if err != nil {
+			return errfmt.Format(err)
+		}
+
+		if err := openBrowser(raindrop.Link); err != nil {
+			return err
+		}
+	}
 
-	return openBrowser(raindrop.Link)
+	return nil
 }
 
 func openBrowser(url string) error {
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/dedene/raindrop-cli/internal/api"
+	"github.com/dedene/raindrop-cli/internal/config"
+	"github.com/dedene/raindrop-cli/internal/errfmt"
+	"github.com/dedene/raindrop-cli/internal/tui"
+)
+
+// pickerModes are the accepted picker config values.
+var pickerModes = []string{"auto", "builtin", "fzf"}
+
+// pickLimit is the number of recent raindrops offered by the picker.
+const pickLimit = 100
+
+// errIDRequired is returned when an ID is omitted and no picker can run.
+var errIDRequired = errors.New("raindrop ID required (omit it only in an interactive terminal to pick one)")
+
+// raindropID resolves a single ID argument (see resolveIDs), or lets the
+// user pick a raindrop when it is empty.
+func raindropID(flags *RootFlags, arg string) (int, error) {
+	var (
+		ids []int
+		err error
+	)
+
+	if arg == "" {
+		ids, err = pickRaindrops(flags, false)
+	} else {
+		ids, err = resolveIDs([]string{arg})
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(ids) != 1 {
+		return 0, &ExitError{Code: ExitUsage, Err: fmt.Errorf("%s refers to %d raindrops; this command takes one", arg, len(ids))}
+	}
+
+	return ids[0], nil
+}
+
+// raindropIDs resolves ID arguments (see resolveIDs), or lets the user pick
+// raindrops when there are none.
+func raindropIDs(flags *RootFlags, args []string) ([]int, error) {
+	if len(args) > 0 {
+		return resolveIDs(args)
+	}
+
+	return pickRaindrops(flags, true)
+}
+
+// pickRaindrops runs a fuzzy finder over recent raindrops: fzf when it is
+// installed (see the picker config key), the built-in finder otherwise.
+// Without a terminal, or with --no-input, an ID is required.
+func pickRaindrops(flags *RootFlags, multi bool) ([]int, error) {
+	if flags.NoInput || !tui.IsTerminal(os.Stderr) {
+		return nil, &ExitError{Code: ExitUsage, Err: errIDRequired}
+	}
+
+	client, err := getClient(flags)
+	if err != nil {
+		return nil, errfmt.Format(err)
+	}
+
+	items, err := pickItems(flags.Context(), client, "", pickLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	fzf, err := fzfPath()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+
+	if fzf != "" {
+		ids, err = pickWithFzf(fzf, items, multi)
+	} else {
+		ids, err = tui.Pick(items, tui.PickOptions{
+			Prompt: "raindrop> ",
+			Multi:  multi,
+			Search: func(q string) ([]tui.PickItem, error) {
+				return pickItems(flags.Context(), client, q, api.MaxPerPage)
+			},
+		})
+	}
+
+	if errors.Is(err, tui.ErrCancelled) {
+		return nil, &ExitError{Code: ExitGeneric, Err: errors.New("no raindrop selected")}
+	}
+
+	return ids, err
+}
+
+// pickItems returns up to limit raindrops, most recent first, matching the
+// search query if one is given.
+func pickItems(ctx context.Context, client *api.Client, search string, limit int) ([]tui.PickItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	opts := api.ListOptions{Search: search, Sort: "-created", PerPage: min(limit, api.MaxPerPage)}
+
+	var items []tui.PickItem
+
+	_, err := eachRaindropPage(ctx, client, api.SystemCollectionAll, opts, listScope{limit: limit}, func(page []api.Raindrop) error {
+		for i := range page {
+			items = append(items, pickItem(&page[i]))
+		}
+
+		return nil
+	})
+
+	return items, err
+}
+
+// pickItem describes a raindrop by title, domain and tags.
+func pickItem(r *api.Raindrop) tui.PickItem {
+	label := r.Title
+	if label == "" {
+		label = r.Link
+	}
+
+	if r.Domain != "" {
+		label += "  · " + r.Domain
+	}
+
+	for _, tag := range r.Tags {
+		label += " #" + tag
+	}
+
+	return tui.PickItem{ID: r.ID, Label: label, Text: label}
+}
+
+// fzfPath returns the fzf binary to use, or "" for the built-in finder.
+func fzfPath() (string, error) {
+	cfg, _ := config.ReadConfig()
+	if cfg.Picker == "builtin" {
+		return "", nil
+	}
+
+	path, err := exec.LookPath("fzf")
+	if err != nil {
+		if cfg.Picker == "fzf" {
+			return "", errors.New("picker is set to fzf but fzf was not found in PATH")
+		}
+
+		return "", nil
+	}
+
+	return path, nil
+}
+
+// pickWithFzf offers items in fzf. fzf draws on the terminal itself and
+// prints the chosen lines, which start with the ID.
+func pickWithFzf(fzf string, items []tui.PickItem, multi bool) ([]int, error) {
+	var in bytes.Buffer
+
+	for _, item := range items {
+		fmt.Fprintf(&in, "%d\t%s\n", item.ID, item.Label)
+	}
+
+	args := []string{"--delimiter=\t", "--with-nth=2..", "--prompt=raindrop> ", "--height=50%", "--reverse"}
+	if multi {
+		args = append(args, "--multi")
+	}
+
+	cmd := exec.Command(fzf, args...) //nolint:gosec // fzf from PATH
+	cmd.Stdin = &in
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		// 1: no match, 130: interrupted with Esc or Ctrl-C.
+		if errors.As(err, &exitErr) && (exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130) {
+			return nil, tui.ErrCancelled
+		}
+
+		return nil, fmt.Errorf("run fzf: %w", err)
+	}
+
+	var ids []int
+
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		field, _, _ := strings.Cut(line, "\t")
+		if id, err := strconv.Atoi(field); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, tui.ErrCancelled
+	}
+
+	return ids, nil
+}
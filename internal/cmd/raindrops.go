@@ -0,0 +1,403 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/dedene/raindrop-cli/internal/api"
+	"github.com/dedene/raindrop-cli/internal/config"
+	"github.com/dedene/raindrop-cli/internal/errfmt"
+	"github.com/dedene/raindrop-cli/internal/output"
+	"github.com/dedene/raindrop-cli/internal/query"
+)
+
+// PageFlags select which part of a raindrop list is fetched.
+type PageFlags struct {
+	All     bool `help:"Fetch all pages (default: first page)" short:"a"`
+	Limit   int  `help:"Return at most N results, following pages as needed" placeholder:"N"`
+	Page    int  `help:"Page to fetch, starting at 1" placeholder:"N"`
+	PerPage int  `help:"Results per page (1-50, default 50)" name:"per-page" placeholder:"N"`
+	Count   bool `help:"Print only the number of matching raindrops"`
+}
+
+// MatchFlags filter listed raindrops client-side.
+type MatchFlags struct {
+	Grep     string `help:"Only results whose title, URL, note, excerpt or tags match this regular expression" placeholder:"REGEX"`
+	URLMatch string `help:"Only results whose URL matches this glob, e.g. 'github.com/our-org/*'" name:"url-match" placeholder:"GLOB"`
+	Case     string `help:"Case sensitivity of --grep and --url-match: smart (ignore case unless the pattern has capitals), sensitive, ignore" enum:"smart,sensitive,ignore" default:"smart"`
+}
+
+// matcher compiles the flags; it returns nil when no filter is set.
+func (m *MatchFlags) matcher() (*query.Matcher, error) {
+	match, err := query.NewMatcher(m.Grep, m.URLMatch, m.Case)
+	if err != nil {
+		return nil, &ExitError{Code: ExitUsage, Err: err}
+	}
+
+	return match, nil
+}
+
+// matchRaindrop reports whether r passes the client-side filters.
+func matchRaindrop(m *query.Matcher, r *api.Raindrop) bool {
+	return m.MatchURL(r.Link) && m.MatchText(append([]string{r.Title, r.Link, r.Note, r.Excerpt}, r.Tags...)...)
+}
+
+// paginated reports whether any paging flag was given. Structured output
+// then includes pagination metadata.
+func (p *PageFlags) paginated() bool {
+	return p.Limit > 0 || p.Page > 0 || p.PerPage > 0
+}
+
+// validate rejects out-of-range values. Commands call it before building
+// the client, so a bad value isn't reported as a missing login.
+func (p *PageFlags) validate() error {
+	switch {
+	case p.Limit < 0:
+		return &ExitError{Code: ExitUsage, Err: fmt.Errorf("invalid --limit: %d", p.Limit)}
+	case p.Page < 0:
+		return &ExitError{Code: ExitUsage, Err: fmt.Errorf("invalid --page: %d (pages start at 1)", p.Page)}
+	case p.PerPage < 0 || p.PerPage > api.MaxPerPage:
+		return &ExitError{Code: ExitUsage, Err: fmt.Errorf("invalid --per-page: %d (must be 1-%d)", p.PerPage, api.MaxPerPage)}
+	}
+
+	return nil
+}
+
+// apply validates the flags and sets the page size and first page on opts.
+// filtered is set when results are filtered client-side.
+func (p *PageFlags) apply(opts *api.ListOptions, filtered bool) error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+
+	opts.PerPage = p.PerPage
+	if opts.PerPage == 0 {
+		opts.PerPage = api.MaxPerPage
+
+		// Don't fetch more than needed.
+		if p.Limit > 0 && p.Limit < opts.PerPage && p.Page == 0 && !filtered {
+			opts.PerPage = p.Limit
+		}
+	}
+
+	if p.Page > 0 {
+		opts.Page = p.Page - 1
+	}
+
+	return nil
+}
+
+// pageInfo describes a fetched range of a raindrop list.
+type pageInfo struct {
+	Total   int  `json:"total"`
+	Page    int  `json:"page"`
+	PerPage int  `json:"perPage"`
+	HasMore bool `json:"hasMore"`
+}
+
+// raindropPage is the structured output of a paginated list.
+type raindropPage struct {
+	Items any `json:"items"`
+	pageInfo
+}
+
+// listScope selects what eachRaindropPage passes on: further pages are
+// followed when all is set or until limit items (0: no limit) were passed,
+// and only items accepted by match are kept. progress, if set, counts the
+// scanned items.
+type listScope struct {
+	all      bool
+	limit    int
+	match    *query.Matcher
+	progress *output.Progress
+}
+
+// eachRaindropPage lists raindrops in a collection starting at opts.Page and
+// calls fn with the items of each page that are in scope.
+func eachRaindropPage(ctx context.Context, client *api.Client, collectionID int, opts api.ListOptions, scope listScope, fn func([]api.Raindrop) error) (pageInfo, error) {
+	info := pageInfo{Page: opts.Page + 1, PerPage: opts.PerPage}
+	if info.PerPage == 0 {
+		info.PerPage = api.MaxPerPage
+	}
+
+	scanned, kept := 0, 0
+
+	defer scope.progress.Done()
+
+	for page := opts.Page; ; page++ {
+		opts.Page = page
+
+		resp, err := client.ListRaindrops(ctx, collectionID, opts)
+		if err != nil {
+			return info, errfmt.Format(err)
+		}
+
+		items := make([]api.Raindrop, 0, len(resp.Items))
+
+		for i := range resp.Items {
+			if scope.limit > 0 && kept+len(items) >= scope.limit {
+				break
+			}
+
+			scanned++
+
+			if matchRaindrop(scope.match, &resp.Items[i]) {
+				items = append(items, resp.Items[i])
+			}
+		}
+
+		if err := fn(items); err != nil {
+			return info, err
+		}
+
+		kept += len(items)
+		info.Total = resp.Count
+		info.HasMore = (info.Page-1)*info.PerPage+scanned < resp.Count
+
+		toScan := resp.Count - (info.Page-1)*info.PerPage
+		if scope.limit > 0 && scope.match == nil {
+			toScan = min(toScan, scope.limit)
+		}
+
+		scope.progress.SetTotal(int64(toScan))
+		scope.progress.Set(int64(scanned))
+
+		// Stop at the end, at the limit, or after one page unless following
+		if len(resp.Items) == 0 || !info.HasMore || (scope.limit > 0 && kept >= scope.limit) || (!scope.all && scope.limit == 0) {
+			return info, nil
+		}
+	}
+}
+
+// fetchRaindrops collects all pages returned by eachRaindropPage.
+func fetchRaindrops(ctx context.Context, client *api.Client, collectionID int, opts api.ListOptions, scope listScope) ([]api.Raindrop, pageInfo, error) {
+	var items []api.Raindrop
+
+	info, err := eachRaindropPage(ctx, client, collectionID, opts, scope, func(page []api.Raindrop) error {
+		items = append(items, page...)
+
+		return nil
+	})
+
+	return items, info, err
+}
+
+// countRaindrops prints the number of raindrops matching opts, fetching a
+// single item rather than a full page. Client-side filters need every page
+// to be scanned.
+func countRaindrops(ctx context.Context, client *api.Client, flags *RootFlags, collectionID int, opts api.ListOptions, match *query.Matcher) error {
+	var total int
+
+	if match == nil {
+		opts.Page, opts.PerPage = 0, 1
+
+		resp, err := client.ListRaindrops(ctx, collectionID, opts)
+		if err != nil {
+			return errfmt.Format(err)
+		}
+
+		total = resp.Count
+	} else {
+		opts.Page, opts.PerPage = 0, api.MaxPerPage
+
+		scope := listScope{all: true, match: match, progress: newProgress(flags, "Scanning raindrops", "raindrops", 0)}
+
+		_, err := eachRaindropPage(ctx, client, collectionID, opts, scope, func(items []api.Raindrop) error {
+			total += len(items)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	count := strconv.Itoa(total)
+	table := &output.Table{Headers: []string{"COUNT"}}
+	table.AddRow([]string{count}, []string{count})
+
+	return newRenderer(flags).Item(map[string]int{"count": total}, table, func(w io.Writer) {
+		fmt.Fprintln(w, count)
+	})
+}
+
+// raindropView is the resolved field selection for raindrop list output.
+type raindropView struct {
+	fields []string
+	// explicit is set when --fields was given; structured modes then emit
+	// only the selected keys instead of full objects.
+	explicit bool
+	fc       *output.FieldContext
+}
+
+// newRaindropView resolves --fields, falling back to the configured
+// default_fields and then to output.DefaultRaindropFields. Collection names
+// are looked up only when the collection column is selected.
+func newRaindropView(ctx context.Context, client *api.Client, flags *RootFlags, spec string) (*raindropView, error) {
+	view := &raindropView{
+		fields:   output.DefaultRaindropFields,
+		explicit: spec != "",
+		fc:       &output.FieldContext{Hyperlinks: flags.HyperlinkMode()},
+	}
+
+	if spec == "" {
+		if cfg, err := config.ReadConfig(); err == nil {
+			spec = cfg.DefaultFields
+		}
+	}
+
+	if spec != "" {
+		fields, err := output.ParseRaindropFields(spec)
+		if err != nil {
+			return nil, &ExitError{Code: ExitUsage, Err: err}
+		}
+
+		view.fields = fields
+	}
+
+	if slices.Contains(view.fields, "collection") {
+		// Names are cosmetic; fall back to IDs if the tree is unavailable.
+		if collections, err := client.Collections(ctx); err == nil {
+			view.fc.Collections = api.CollectionPaths(collections)
+		}
+	}
+
+	return view, nil
+}
+
+// listRaindrops fetches and writes a raindrop list in the selected output
+// mode. Streaming outputs (ndjson, --jq, --template) are written page by
+// page; other modes need the complete list. Table mode prints empty as the
+// whole output when there are no items, and a "<n> <noun>(s)" footer
+// otherwise. With paging flags, JSON and YAML wrap the items with the
+// pagination metadata. Client-side matches are highlighted in table cells.
+func listRaindrops(ctx context.Context, client *api.Client, flags *RootFlags, view *raindropView, collectionID int, opts api.ListOptions, pf PageFlags, mf MatchFlags, empty, noun string) error {
+	match, err := mf.matcher()
+	if err != nil {
+		return err
+	}
+
+	if err := pf.apply(&opts, match != nil); err != nil {
+		return err
+	}
+
+	if pf.Count {
+		return countRaindrops(ctx, client, flags, collectionID, opts, match)
+	}
+
+	r := newRenderer(flags)
+	scope := listScope{all: pf.All, limit: pf.Limit, match: match}
+
+	// Following pages can take a while.
+	if pf.All || pf.Limit > opts.PerPage {
+		scope.progress = newProgress(flags, "Fetching raindrops", "raindrops", 0)
+	}
+
+	if match != nil && r.Human() {
+		view.fc.Mark = func(s string) string { return match.Mark(s, output.MarkMatch) }
+	}
+
+	if r.Streaming() {
+		var ids []int
+
+		_, err := eachRaindropPage(ctx, client, collectionID, opts, scope, func(items []api.Raindrop) error {
+			for _, item := range items {
+				ids = append(ids, item.ID)
+			}
+
+			return r.List(view.data(r, items), nil)
+		})
+		// The rows written before an interrupt keep their handles.
+		if err == nil || errors.Is(err, context.Canceled) {
+			saveHandles(ids)
+		}
+
+		return err
+	}
+
+	// On an interrupt, the pages fetched so far are still written, even if
+	// there are none, so that structured output stays valid.
+	items, info, fetchErr := fetchRaindrops(ctx, client, collectionID, opts, scope)
+
+	interrupted := errors.Is(fetchErr, context.Canceled)
+	if fetchErr != nil && !interrupted {
+		return fetchErr
+	}
+
+	if interrupted {
+		info.HasMore = true
+	}
+
+	if items == nil {
+		items = []api.Raindrop{}
+	}
+
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	saveHandles(ids)
+
+	if pf.paginated() && (r.Mode == output.ModeJSON || r.Mode == output.ModeYAML) {
+		if err := r.Item(raindropPage{Items: view.data(r, items), pageInfo: info}, nil, nil); err != nil {
+			return err
+		}
+
+		return fetchErr
+	}
+
+	if r.Human() && len(items) == 0 {
+		if !interrupted {
+			fmt.Fprintln(os.Stdout, empty)
+		}
+
+		return fetchErr
+	}
+
+	table := output.RaindropTable(items, view.fields, view.fc)
+	if r.Human() {
+		// Row numbers are the @N handles of this listing.
+		table.Number()
+	}
+
+	if err := r.List(view.data(r, items), table); err != nil {
+		return err
+	}
+
+	if r.Human() {
+		switch {
+		case interrupted:
+			fmt.Fprintf(os.Stdout, "\n%d %s(s); interrupted before the last page\n", len(items), noun)
+		case info.HasMore && match != nil:
+			fmt.Fprintf(os.Stdout, "\n%d %s(s); more not scanned, use --all or --limit\n", len(items), noun)
+		case info.HasMore:
+			fmt.Fprintf(os.Stdout, "\n%d of %d %s(s); use --all, --limit or --page for more\n", len(items), info.Total, noun)
+		default:
+			fmt.Fprintf(os.Stdout, "\n%d %s(s)\n", len(items), noun)
+		}
+	}
+
+	return fetchErr
+}
+
+// data returns the value structured renderers encode: full raindrops, or
+// records reduced to the selected fields when --fields was given.
+func (v *raindropView) data(r *output.Renderer, items []api.Raindrop) any {
+	if v.explicit && r.Template == nil {
+		return output.RaindropRecords(items, v.fields, v.fc)
+	}
+
+	return items
+}
+
+// raindropTable returns the default tabular form of raindrops, used by
+// commands that print single items.
+func raindropTable(flags *RootFlags, items ...api.Raindrop) *output.Table {
+	return output.RaindropTable(items, nil, &output.FieldContext{Hyperlinks: flags.HyperlinkMode()})
+}
@@ -1,21 +1,161 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"text/template"
+	"time"
 
 	"github.com/alecthomas/kong"
 
+	"github.com/dedene/raindrop-cli/internal/config"
 	"github.com/dedene/raindrop-cli/internal/output"
 )
 
 type RootFlags struct {
-	JSON       bool   `help:"Output JSON to stdout (best for scripting)"`
+	Output     string `help:"Output format: table, json, ndjson, csv, tsv, yaml, markdown (config: default_output)" short:"o" default:"table" enum:"table,json,ndjson,csv,tsv,yaml,markdown"`
+	JSON       bool   `help:"Output JSON to stdout (alias for --output json)"`
 	Verbose    bool   `help:"Enable verbose logging"`
 	Force      bool   `help:"Skip confirmations"`
 	NoInput    bool   `help:"Fail instead of prompting (CI mode)" name:"no-input"`
-	Hyperlinks string `help:"Hyperlink mode: auto, on, off" default:"auto" enum:"auto,on,off"`
+	Hyperlinks string `help:"Hyperlink mode: auto, on, off (config: hyperlinks)" default:"auto" enum:"auto,on,off"`
+	Refresh    bool   `help:"Refresh cached collection metadata"`
+	Dates      string `help:"Date display: absolute, relative, iso (config: dates)" default:"absolute" enum:"absolute,relative,iso"`
+
+	Template     string `help:"Format each item with a Go template (overrides --output)"`
+	TemplateFile string `help:"Read the --template from a file" type:"existingfile"`
+	JQ           string `help:"Filter JSON output with a jq expression (applied per item for lists)" name:"jq"`
+	Raw          bool   `help:"Print --jq string results without quotes" short:"r"`
+
+	template       *template.Template
+	jq             *output.JQ
+	applied        bool
+	outputExplicit bool
+	ctx            context.Context
+}
+
+// AfterApply fills unset flags from the config file, then parses --jq,
+// --template or --template-file. Kong calls it for both CLI and the embedded
+// RootFlags, so it runs only once.
+func (f *RootFlags) AfterApply(kctx *kong.Context, cfg *config.File) error {
+	if f.applied {
+		return nil
+	}
+
+	f.applied = true
+
+	f.applyConfig(kctx, cfg)
+
+	if style, err := output.ParseDateStyle(f.Dates); err == nil {
+		output.SetDateStyle(style)
+	}
+
+	return f.parseFormatters()
+}
+
+// applyConfig uses default_output, dates and hyperlinks from the config file for
+// flags not given on the command line. Invalid values are ignored with a
+// warning.
+func (f *RootFlags) applyConfig(kctx *kong.Context, cfg *config.File) {
+	explicit := make(map[string]bool)
+
+	for _, p := range kctx.Path {
+		if p.Flag != nil {
+			explicit[p.Flag.Name] = true
+		}
+	}
+
+	f.outputExplicit = explicit["output"] || f.JSON
+
+	if cfg.DefaultOutput != "" && !explicit["output"] {
+		if _, err := output.ParseMode(cfg.DefaultOutput); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: ignoring default_output in config: %v\n", err)
+		} else {
+			f.Output = cfg.DefaultOutput
+		}
+	}
+
+	if cfg.Dates != "" && !explicit["dates"] {
+		if _, err := output.ParseDateStyle(cfg.Dates); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: ignoring dates in config: %v\n", err)
+		} else {
+			f.Dates = cfg.Dates
+		}
+	}
+
+	if cfg.Hyperlinks != "" && !explicit["hyperlinks"] {
+		if !slices.Contains(hyperlinkModes, cfg.Hyperlinks) {
+			fmt.Fprintf(os.Stderr, "warning: ignoring hyperlinks in config: %s (must be auto, on or off)\n", cfg.Hyperlinks)
+		} else {
+			f.Hyperlinks = cfg.Hyperlinks
+		}
+	}
+}
+
+// useOutput selects mode unless an output format was given on the command
+// line. Saved searches use it to apply their stored format.
+func (f *RootFlags) useOutput(mode string) {
+	if mode == "" || f.outputExplicit {
+		return
+	}
+
+	if _, err := output.ParseMode(mode); err == nil {
+		f.Output = mode
+	}
+}
+
+// hyperlinkModes are the accepted --hyperlinks values.
+var hyperlinkModes = []string{"auto", "on", "off"}
+
+// parseFormatters compiles --jq or the --template/--template-file text.
+func (f *RootFlags) parseFormatters() error {
+	if f.JQ != "" {
+		if f.Template != "" || f.TemplateFile != "" {
+			return &ExitError{Code: ExitUsage, Err: errors.New("--jq cannot be combined with --template")}
+		}
+
+		jq, err := output.ParseJQ(f.JQ, f.Raw)
+		if err != nil {
+			return &ExitError{Code: ExitUsage, Err: err}
+		}
+
+		f.jq = jq
+
+		return nil
+	}
+
+	text := f.Template
+
+	if f.TemplateFile != "" {
+		if text != "" {
+			return &ExitError{Code: ExitUsage, Err: errors.New("--template and --template-file are mutually exclusive")}
+		}
+
+		b, err := os.ReadFile(f.TemplateFile)
+		if err != nil {
+			return fmt.Errorf("read template file: %w", err)
+		}
+
+		text = string(b)
+	}
+
+	if text == "" {
+		return nil
+	}
+
+	tmpl, err := output.ParseTemplate(text, f.HyperlinkMode())
+	if err != nil {
+		return &ExitError{Code: ExitUsage, Err: err}
+	}
+
+	f.template = tmpl
+
+	return nil
 }
 
 // HyperlinkMode returns the parsed hyperlink mode.
@@ -30,6 +170,29 @@ func (f *RootFlags) HyperlinkMode() output.HyperlinkMode {
 	}
 }
 
+// Context returns the root context, cancelled on SIGINT or SIGTERM.
+func (f *RootFlags) Context() context.Context {
+	if f.ctx == nil {
+		return context.Background()
+	}
+
+	return f.ctx
+}
+
+// OutputMode returns the selected output mode; --json wins over --output.
+func (f *RootFlags) OutputMode() output.Mode {
+	if f.JSON {
+		return output.ModeJSON
+	}
+
+	mode, err := output.ParseMode(f.Output)
+	if err != nil {
+		return output.ModeTable
+	}
+
+	return mode
+}
+
 type CLI struct {
 	RootFlags `embed:""`
 
@@ -43,8 +206,10 @@ type CLI struct {
 	List        ListCmd        `cmd:"" help:"List bookmarks"`
 	Get         GetCmd         `cmd:"" help:"Get bookmark details"`
 	Update      UpdateCmd      `cmd:"" help:"Update a bookmark"`
+	Edit        EditCmd        `cmd:"" help:"Edit a bookmark in $VISUAL or $EDITOR"`
 	Delete      DeleteCmd      `cmd:"" help:"Delete a bookmark"`
 	Search      SearchCmd      `cmd:"" help:"Search bookmarks"`
+	Searches    SearchesCmd    `cmd:"" help:"Manage saved searches"`
 	Collections CollectionsCmd `cmd:"" help:"Manage collections"`
 	Tags        TagsCmd        `cmd:"" help:"Manage tags"`
 	Highlights  HighlightsCmd  `cmd:"" help:"Manage highlights"`
@@ -54,13 +219,30 @@ type CLI struct {
 	Export     ExportCmd     `cmd:"" help:"Export bookmarks"`
 	Open       OpenCmd       `cmd:"" help:"Open bookmark in browser"`
 	Copy       CopyCmd       `cmd:"" help:"Copy bookmark URL to clipboard"`
+	Tui        TuiCmd        `cmd:"" name:"tui" help:"Browse bookmarks in an interactive terminal UI"`
 	Completion CompletionCmd `cmd:"" help:"Generate shell completions"`
+
+	// Internal commands
+	Complete CompleteCmd `cmd:"" name:"__complete" hidden:"" help:"Print shell completion candidates"`
+	GenDocs  GenDocsCmd  `cmd:"" name:"gen-docs" hidden:"" help:"Generate man pages or markdown reference"`
 }
 
 type exitPanic struct{ code int }
 
 func Execute(args []string) (err error) {
-	parser, err := newParser()
+	cfg := loadConfig()
+
+	// The first SIGINT or SIGTERM cancels ctx, so commands can stop their
+	// requests and report what they finished; a second one exits at once.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	parser, err := newParser(ctx, &cfg)
 	if err != nil {
 		return err
 	}
@@ -91,12 +273,20 @@ func Execute(args []string) (err error) {
 	kctx, err := parser.Parse(args)
 	if err != nil {
 		parsedErr := wrapParseError(err)
+		if hint := exportFileHint(args); hint != nil {
+			parsedErr = hint
+		}
+
 		_, _ = fmt.Fprintln(os.Stderr, parsedErr)
 
 		return parsedErr
 	}
 
 	err = kctx.Run()
+	if ctx.Err() != nil && errors.Is(err, context.Canceled) {
+		err = &ExitError{Code: ExitInterrupted, Err: errInterrupted}
+	}
+
 	if err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err)
 
@@ -119,12 +309,34 @@ func wrapParseError(err error) error {
 	return err
 }
 
-func newParser() (*kong.Kong, error) {
+// loadConfig reads the config file and applies its timezone. Problems are
+// reported as warnings so a broken config never blocks the CLI.
+func loadConfig() config.File {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+
+		return config.File{}
+	}
+
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: ignoring timezone in config: %v\n", err)
+		} else {
+			output.SetLocation(loc)
+		}
+	}
+
+	return cfg
+}
+
+func newParser(ctx context.Context, cfg *config.File) (*kong.Kong, error) {
 	vars := kong.Vars{
 		"version": VersionString(),
 	}
 
-	cli := &CLI{}
+	cli := &CLI{RootFlags: RootFlags{ctx: ctx}}
 	parser, err := kong.New(
 		cli,
 		kong.Name("raindrop"),
@@ -132,7 +344,7 @@ func newParser() (*kong.Kong, error) {
 		kong.Vars(vars),
 		kong.Writers(os.Stdout, os.Stderr),
 		kong.Exit(func(code int) { panic(exitPanic{code: code}) }),
-		kong.Bind(&cli.RootFlags),
+		kong.Bind(&cli.RootFlags, cfg),
 		kong.Help(helpPrinter),
 		kong.ConfigureHelp(helpOptions()),
 	)
@@ -2,110 +2,114 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/dedene/raindrop-cli/internal/api"
 	"github.com/dedene/raindrop-cli/internal/errfmt"
 	"github.com/dedene/raindrop-cli/internal/output"
+	"github.com/dedene/raindrop-cli/internal/query"
 )
 
 type SearchCmd struct {
-	Query      string `arg:"" optional:"" help:"Search query"`
-	Tag        string `help:"Filter by tag" short:"t"`
-	Type       string `help:"Filter by type (link|article|image|video|document|audio)" short:"T"`
-	After      string `help:"Created after date (YYYY-MM-DD)"`
-	Before     string `help:"Created before date (YYYY-MM-DD)"`
-	Collection string `help:"Collection to search" default:"0" short:"c"`
-	All        bool   `help:"Fetch all results" short:"a"`
+	Query         string `arg:"" optional:"" help:"Search query, or @name to run a saved search"`
+	SearchFilters `embed:""`
+	Collection    string `help:"Collection to search" default:"0" short:"c"`
+	Fields        string `help:"Comma-separated fields to show (e.g. id,title,tags,collection)"`
+	PageFlags     `embed:""`
+	MatchFlags    `embed:""`
 }
 
-func (c *SearchCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
-	if err != nil {
-		return errfmt.Format(err)
-	}
-	defer cancel()
-
-	collectionID, err := client.ResolveCollection(ctx, c.Collection)
-	if err != nil {
-		return errfmt.Format(err)
-	}
-
-	search := c.buildSearch()
-	if search == "" {
-		return fmt.Errorf("search query required; use positional arg or --tag, --type, --after, --before")
-	}
+// SearchFilters are the filter flags of the search command.
+type SearchFilters struct {
+	Tag           []string `help:"Filter by tag (repeatable)" short:"t"`
+	Match         string   `help:"Match all tags (AND) or any (OR; can't be combined with other filters)" enum:"all,any" default:"all"`
+	ExcludeTag    []string `help:"Exclude tag (repeatable)" name:"exclude-tag"`
+	Type          string   `help:"Filter by type (link|article|image|video|document|audio)" short:"T"`
+	Domain        string   `help:"Only bookmarks whose URL contains this domain"`
+	InTitle       string   `help:"Search only in titles" name:"in-title"`
+	InNote        string   `help:"Search only in notes" name:"in-note"`
+	Favorites     bool     `help:"Only favorites"`
+	Untagged      bool     `help:"Only bookmarks without tags"`
+	HasNote       bool     `help:"Only bookmarks with a note" name:"has-note"`
+	HasHighlights bool     `help:"Only bookmarks with highlights" name:"has-highlights"`
+	Broken        bool     `help:"Only broken links"`
+	Duplicates    bool     `help:"Only duplicates"`
+	After         string   `help:"Created on or after (YYYY-MM-DD, 7d, 2w, last-month, 2026-Q1, ...)"`
+	Before        string   `help:"Created on or before (YYYY-MM-DD, 7d, 2w, last-month, 2026-Q1, ...)"`
+}
 
-	opts := api.ListOptions{
-		Search:  search,
-		Sort:    "score", // relevance for search
-		PerPage: 50,
+// Filter returns the flags as a query filter with the given free text.
+func (s *SearchFilters) Filter(text string) query.Filter {
+	return query.Filter{
+		Text:          text,
+		Tags:          s.Tag,
+		AnyTag:        s.Match == "any",
+		ExcludeTags:   s.ExcludeTag,
+		Type:          s.Type,
+		Domain:        s.Domain,
+		InTitle:       s.InTitle,
+		InNote:        s.InNote,
+		Favorites:     s.Favorites,
+		Untagged:      s.Untagged,
+		HasNote:       s.HasNote,
+		HasHighlights: s.HasHighlights,
+		Broken:        s.Broken,
+		Duplicates:    s.Duplicates,
+		After:         s.After,
+		Before:        s.Before,
 	}
+}
 
-	var allItems []api.Raindrop
-
-	page := 0
-
-	for {
-		opts.Page = page
+func (c *SearchCmd) Run(flags *RootFlags) error {
+	filter := c.Filter(c.Query)
+	collection, sort, fields := c.Collection, "", c.Fields
 
-		resp, err := client.ListRaindrops(ctx, collectionID, opts)
+	if name, ok := savedSearchRef(c.Query); ok {
+		saved, err := loadSavedSearch(name)
 		if err != nil {
-			return errfmt.Format(err)
+			return err
 		}
 
-		allItems = append(allItems, resp.Items...)
-
-		if !c.All || len(allItems) >= resp.Count {
-			break
-		}
-
-		page++
+		filter = saved.Merge(c.Filter(""))
+		collection, sort, fields = applySavedSearch(flags, saved.SavedSearch, collection, sort, fields)
 	}
 
-	if flags.JSON {
-		return output.WriteJSON(os.Stdout, allItems)
+	if sort == "" {
+		sort = "score" // relevance for search
 	}
 
-	if len(allItems) == 0 {
-		fmt.Fprintln(os.Stdout, "No results found.")
-
-		return nil
+	search, err := filter.Build(output.Now())
+	if err != nil {
+		return &ExitError{Code: ExitUsage, Err: err}
 	}
 
-	tw := output.NewTableWriter(os.Stdout, output.RaindropTableHeaders()...)
-	for _, r := range allItems {
-		tw.AddRow(output.FormatRaindropRow(&r, flags.HyperlinkMode())...)
+	if search == "" && c.Grep == "" && c.URLMatch == "" {
+		return fmt.Errorf("search query required; use positional arg or filter flags such as --tag, --type, --after, --grep")
 	}
 
-	tw.Render()
-	fmt.Fprintf(os.Stdout, "\n%d result(s)\n", len(allItems))
-
-	return nil
-}
-
-func (c *SearchCmd) buildSearch() string {
-	var parts []string
-
-	if c.Query != "" {
-		parts = append(parts, c.Query)
+	if err := c.PageFlags.validate(); err != nil {
+		return err
 	}
 
-	if c.Tag != "" {
-		parts = append(parts, "#"+c.Tag)
+	client, ctx, cancel, err := getClientWithContext(flags)
+	if err != nil {
+		return errfmt.Format(err)
 	}
+	defer cancel()
 
-	if c.Type != "" {
-		parts = append(parts, "type:"+c.Type)
+	collectionID, err := client.ResolveCollection(ctx, collection)
+	if err != nil {
+		return errfmt.Format(err)
 	}
 
-	if c.After != "" {
-		parts = append(parts, "created:>"+c.After)
+	opts := api.ListOptions{
+		Search: search,
+		Sort:   sort,
 	}
 
-	if c.Before != "" {
-		parts = append(parts, "created:<"+c.Before)
+	view, err := newRaindropView(ctx, client, flags, fields)
+	if err != nil {
+		return err
 	}
 
-	return joinSearchParts(parts)
+	return listRaindrops(ctx, client, flags, view, collectionID, opts, c.PageFlags, c.MatchFlags, "No results found.", "result")
 }
@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/dedene/raindrop-cli/internal/config"
+	"github.com/dedene/raindrop-cli/internal/output"
+)
+
+// savedSearchPrefix marks a saved search reference, as in "search @daily".
+const savedSearchPrefix = "@"
+
+// sortOrders are the accepted raindrop sort orders.
+var sortOrders = []string{"created", "-created", "title", "-title", "domain", "-domain", "score"}
+
+type SearchesCmd struct {
+	List   SearchesListCmd   `cmd:"" default:"1" help:"List saved searches"`
+	Save   SearchesSaveCmd   `cmd:"" help:"Save a search"`
+	Delete SearchesDeleteCmd `cmd:"" help:"Delete a saved search"`
+}
+
+type SearchesListCmd struct{}
+
+// savedSearchEntry is the structured output form of a saved search.
+type savedSearchEntry struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Query  string `json:"query"`
+	config.SavedSearch
+}
+
+func (c *SearchesListCmd) Run(flags *RootFlags) error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	searches, err := config.Searches(cfg)
+	if err != nil {
+		return err
+	}
+
+	r := newRenderer(flags)
+
+	if r.Human() && len(searches) == 0 {
+		fmt.Fprintln(os.Stdout, "No saved searches. Create one with: raindrop searches save <name> [query] [filters]")
+
+		return nil
+	}
+
+	now := output.Now()
+	entries := make([]savedSearchEntry, 0, len(searches))
+	table := savedSearchTable()
+
+	for _, s := range searches {
+		q, buildErr := s.Build(now)
+		if buildErr != nil {
+			q = "invalid: " + buildErr.Error()
+		}
+
+		entries = append(entries, savedSearchEntry{Name: s.Name, Source: s.Source, Query: q, SavedSearch: s.SavedSearch})
+
+		addSavedSearchRow(table, s.Name, q, s.Collection, s.Source)
+	}
+
+	return r.List(entries, table)
+}
+
+// savedSearchTable returns an empty table of saved searches.
+func savedSearchTable() *output.Table {
+	return &output.Table{Headers: []string{"NAME", "QUERY", "COLLECTION", "SOURCE"}, Flexible: []int{1}}
+}
+
+func addSavedSearchRow(table *output.Table, name, query, collection, source string) {
+	row := []string{savedSearchPrefix + name, query, collection, source}
+	table.AddRow(row, row)
+}
+
+type SearchesSaveCmd struct {
+	Name          string `arg:"" help:"Name to save the search as"`
+	Query         string `arg:"" optional:"" help:"Search query"`
+	SearchFilters `embed:""`
+	Collection    string `help:"Collection name, path or ID" short:"c"`
+	Sort          string `help:"Sort order (created, -created, title, -title, domain, -domain, score)"`
+	Fields        string `help:"Comma-separated fields to show"`
+	Format        string `help:"Output format to use (table, json, ndjson, csv, tsv, yaml, markdown)"`
+	Shared        bool   `help:"Save to the shared searches file (config: searches_file)"`
+}
+
+func (c *SearchesSaveCmd) Run(flags *RootFlags) error {
+	name, err := validSearchName(c.Name)
+	if err != nil {
+		return err
+	}
+
+	saved := config.SavedSearch{
+		Filter:     c.Filter(c.Query),
+		Collection: c.Collection,
+		Sort:       c.Sort,
+		Fields:     c.Fields,
+		Output:     c.Format,
+	}
+
+	if err := validateSavedSearch(&saved); err != nil {
+		return &ExitError{Code: ExitUsage, Err: err}
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	if c.Shared {
+		if cfg.SearchesFile == "" {
+			return errors.New("no shared searches file configured; set one with: raindrop config set searches_file <path>")
+		}
+
+		shared, err := config.ReadSharedSearches(cfg.SearchesFile)
+		if err != nil {
+			return err
+		}
+
+		shared[name] = saved
+
+		if err := config.WriteSharedSearches(cfg.SearchesFile, shared); err != nil {
+			return err
+		}
+	} else {
+		if cfg.Searches == nil {
+			cfg.Searches = make(map[string]config.SavedSearch)
+		}
+
+		cfg.Searches[name] = saved
+
+		if err := config.WriteConfig(cfg); err != nil {
+			return fmt.Errorf("write config: %w", err)
+		}
+	}
+
+	q, _ := saved.Build(output.Now())
+	source := sourceOf(c.Shared)
+
+	table := savedSearchTable()
+	addSavedSearchRow(table, name, q, saved.Collection, source)
+
+	return newRenderer(flags).Item(savedSearchEntry{Name: name, Source: source, Query: q, SavedSearch: saved}, table, func(w io.Writer) {
+		fmt.Fprintf(w, "Saved search %s%s: %s\n", savedSearchPrefix, name, q)
+	})
+}
+
+type SearchesDeleteCmd struct {
+	Name   string `arg:"" help:"Saved search name"`
+	Shared bool   `help:"Delete from the shared searches file"`
+}
+
+func (c *SearchesDeleteCmd) Run(flags *RootFlags) error {
+	name := strings.TrimPrefix(c.Name, savedSearchPrefix)
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	if c.Shared {
+		if cfg.SearchesFile == "" {
+			return errors.New("no shared searches file configured")
+		}
+
+		shared, err := config.ReadSharedSearches(cfg.SearchesFile)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := shared[name]; !ok {
+			return &ExitError{Code: ExitNotFound, Err: fmt.Errorf("%w: %s", config.ErrSearchNotFound, name)}
+		}
+
+		if !confirmAction(fmt.Sprintf("Delete shared search %s%s?", savedSearchPrefix, name), flags) {
+			fmt.Fprintln(os.Stdout, "Cancelled.")
+
+			return nil
+		}
+
+		delete(shared, name)
+
+		if err := config.WriteSharedSearches(cfg.SearchesFile, shared); err != nil {
+			return err
+		}
+	} else {
+		if _, ok := cfg.Searches[name]; !ok {
+			err := fmt.Errorf("%w: %s", config.ErrSearchNotFound, name)
+
+			if cfg.SearchesFile != "" {
+				if shared, readErr := config.ReadSharedSearches(cfg.SearchesFile); readErr == nil {
+					if _, ok := shared[name]; ok {
+						err = fmt.Errorf("%w; it is a shared search, delete it with: raindrop searches delete %s --shared", err, name)
+					}
+				}
+			}
+
+			return &ExitError{Code: ExitNotFound, Err: err}
+		}
+
+		if !confirmAction(fmt.Sprintf("Delete saved search %s%s?", savedSearchPrefix, name), flags) {
+			fmt.Fprintln(os.Stdout, "Cancelled.")
+
+			return nil
+		}
+
+		delete(cfg.Searches, name)
+
+		if err := config.WriteConfig(cfg); err != nil {
+			return fmt.Errorf("write config: %w", err)
+		}
+	}
+
+	fmt.Fprintf(os.Stdout, "Deleted saved search %s%s\n", savedSearchPrefix, name)
+
+	return nil
+}
+
+// savedSearchRef returns the name in a "@name" reference.
+func savedSearchRef(s string) (string, bool) {
+	name, ok := strings.CutPrefix(s, savedSearchPrefix)
+
+	return name, ok && name != ""
+}
+
+// loadSavedSearch looks up a saved search by name.
+func loadSavedSearch(name string) (config.NamedSearch, error) {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return config.NamedSearch{}, fmt.Errorf("read config: %w", err)
+	}
+
+	s, err := config.LookupSearch(cfg, name)
+	if errors.Is(err, config.ErrSearchNotFound) {
+		return s, &ExitError{Code: ExitNotFound, Err: fmt.Errorf("%w (see: raindrop searches list)", err)}
+	}
+
+	return s, err
+}
+
+// applySavedSearch fills in the collection, sort order, fields and output
+// format stored with a saved search where the command line left defaults.
+func applySavedSearch(flags *RootFlags, s config.SavedSearch, collection, sort, fields string) (string, string, string) {
+	if s.Collection != "" && (collection == "" || collection == "0") {
+		collection = s.Collection
+	}
+
+	if sort == "" {
+		sort = s.Sort
+	}
+
+	if fields == "" {
+		fields = s.Fields
+	}
+
+	flags.useOutput(s.Output)
+
+	return collection, sort, fields
+}
+
+func validSearchName(name string) (string, error) {
+	name = strings.TrimPrefix(strings.TrimSpace(name), savedSearchPrefix)
+	if name == "" || strings.ContainsAny(name, " \t/") {
+		return "", &ExitError{Code: ExitUsage, Err: fmt.Errorf("invalid search name: %q (use letters, digits, - or _)", name)}
+	}
+
+	return name, nil
+}
+
+func validateSavedSearch(s *config.SavedSearch) error {
+	q, err := s.Build(output.Now())
+	if err != nil {
+		return err
+	}
+
+	if q == "" && s.Collection == "" {
+		return errors.New("nothing to save; give a query, filter flags or --collection")
+	}
+
+	if s.Sort != "" && !slices.Contains(sortOrders, s.Sort) {
+		return fmt.Errorf("invalid sort order: %s (must be one of %s)", s.Sort, strings.Join(sortOrders, ", "))
+	}
+
+	if s.Fields != "" {
+		if _, err := output.ParseRaindropFields(s.Fields); err != nil {
+			return err
+		}
+	}
+
+	if s.Output != "" {
+		if _, err := output.ParseMode(s.Output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sourceOf(shared bool) string {
+	if shared {
+		return config.SearchSourceShared
+	}
+
+	return config.SearchSourceConfig
+}
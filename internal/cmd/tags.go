@@ -17,11 +17,11 @@ type TagsCmd struct {
 }
 
 type TagsListCmd struct {
-	Collection string `help:"Collection name/ID (default: all)" default:"0" short:"c"`
+	Collection string `help:"Collection name, path or ID (default: all)" default:"0" short:"c"`
 }
 
 func (c *TagsListCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
@@ -37,23 +37,21 @@ func (c *TagsListCmd) Run(flags *RootFlags) error {
 		return errfmt.Format(err)
 	}
 
-	if flags.JSON {
-		return output.WriteJSON(os.Stdout, tags)
-	}
+	r := newRenderer(flags)
 
-	if len(tags) == 0 {
+	if r.Human() && len(tags) == 0 {
 		fmt.Fprintln(os.Stdout, "No tags found.")
 
 		return nil
 	}
 
-	tw := output.NewTableWriter(os.Stdout, "TAG", "COUNT")
-	for _, t := range tags {
-		tw.AddRow(t.Tag, fmt.Sprintf("%d", t.Count))
+	if err := r.List(tags, output.TagTable(tags)); err != nil {
+		return err
 	}
 
-	tw.Render()
-	fmt.Fprintf(os.Stdout, "\n%d tag(s)\n", len(tags))
+	if r.Human() {
+		fmt.Fprintf(os.Stdout, "\n%d tag(s)\n", len(tags))
+	}
 
 	return nil
 }
@@ -61,11 +59,11 @@ func (c *TagsListCmd) Run(flags *RootFlags) error {
 type TagsRenameCmd struct {
 	Old        string `arg:"" help:"Current tag name"`
 	New        string `arg:"" help:"New tag name"`
-	Collection string `help:"Collection name/ID (default: all)" default:"0" short:"c"`
+	Collection string `help:"Collection name, path or ID (default: all)" default:"0" short:"c"`
 }
 
 func (c *TagsRenameCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
@@ -88,11 +86,11 @@ func (c *TagsRenameCmd) Run(flags *RootFlags) error {
 type TagsMergeCmd struct {
 	Tags       string `arg:"" help:"Tags to merge (comma-separated)"`
 	Into       string `help:"Target tag name" required:""`
-	Collection string `help:"Collection name/ID (default: all)" default:"0" short:"c"`
+	Collection string `help:"Collection name, path or ID (default: all)" default:"0" short:"c"`
 }
 
 func (c *TagsMergeCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
@@ -128,11 +126,11 @@ func (c *TagsMergeCmd) Run(flags *RootFlags) error {
 
 type TagsDeleteCmd struct {
 	Tags       string `arg:"" help:"Tags to delete (comma-separated)"`
-	Collection string `help:"Collection name/ID (default: all)" default:"0" short:"c"`
+	Collection string `help:"Collection name, path or ID (default: all)" default:"0" short:"c"`
 }
 
 func (c *TagsDeleteCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dedene/raindrop-cli/internal/errfmt"
+	"github.com/dedene/raindrop-cli/internal/tui"
+)
+
+type TuiCmd struct {
+	Collection string `arg:"" optional:"" help:"Collection name, path or ID to start in (default: all)" default:"0"`
+	Search     string `help:"Initial search query" short:"s"`
+}
+
+func (c *TuiCmd) Run(flags *RootFlags) error {
+	client, err := getClient(flags)
+	if err != nil {
+		return errfmt.Format(err)
+	}
+
+	ctx, cancel := context.WithTimeout(flags.Context(), defaultTimeout)
+	collectionID, err := client.ResolveCollection(ctx, c.Collection)
+
+	cancel()
+
+	if err != nil {
+		return errfmt.Format(err)
+	}
+
+	err = tui.Run(flags.Context(), tui.Options{
+		Client:       client,
+		CollectionID: collectionID,
+		Search:       c.Search,
+		Open:         openBrowser,
+		Copy:         copyToClipboard,
+		FormatError:  errfmt.Format,
+	})
+	if errors.Is(err, tui.ErrNotTerminal) {
+		return &ExitError{Code: ExitUsage, Err: err}
+	}
+
+	return err
+}
@@ -1,96 +1,279 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/dedene/raindrop-cli/internal/api"
+	"github.com/dedene/raindrop-cli/internal/bulk"
 	"github.com/dedene/raindrop-cli/internal/errfmt"
 	"github.com/dedene/raindrop-cli/internal/output"
 )
 
 type UpdateCmd struct {
-	ID         int      `arg:"" help:"Raindrop ID"`
+	IDs        []string `arg:"" optional:"" name:"id" help:"Raindrop IDs or handles such as @3, @1..@5 (omit to pick interactively)"`
 	Title      string   `help:"New title" short:"t"`
+	Link       string   `help:"New URL"`
 	Collection string   `help:"Move to collection" short:"c"`
 	Tags       []string `help:"Replace tags" short:"T"`
+	AddTag     []string `help:"Add tags, keeping the others" name:"add-tag"`
+	RemoveTag  []string `help:"Remove tags" name:"remove-tag"`
+	ClearTags  bool     `help:"Remove all tags" name:"clear-tags"`
 	Note       string   `help:"Update note" short:"n"`
+	ClearNote  bool     `help:"Remove the note" name:"clear-note"`
+	Excerpt    *string  `help:"New excerpt; an empty value clears it"`
+	Cover      *string  `help:"New cover image URL; an empty value clears it"`
 	Favorite   bool     `help:"Mark as favorite" short:"f"`
 	Unfavorite bool     `help:"Remove favorite"`
 }
 
+// raindropUpdate is the result of updating one raindrop: the changed fields
+// and the updated raindrop, if anything changed.
+type raindropUpdate struct {
+	ID      int                  `json:"id"`
+	Changes []output.FieldChange `json:"changes"`
+	Item    *api.Raindrop        `json:"item,omitempty"`
+}
+
 func (c *UpdateCmd) Run(flags *RootFlags) error {
-	client, ctx, cancel, err := getClientWithContext()
+	if !c.hasChanges() {
+		return fmt.Errorf("no changes specified; use --title, --link, --tags, --add-tag, --remove-tag, --clear-tags, --note, --clear-note, --excerpt, --cover, --collection, --favorite, or --unfavorite")
+	}
+
+	if err := c.validate(); err != nil {
+		return &ExitError{Code: ExitUsage, Err: err}
+	}
+
+	ids, err := raindropIDs(flags, c.IDs)
+	if err != nil {
+		return err
+	}
+
+	client, ctx, cancel, err := getClientWithContext(flags)
 	if err != nil {
 		return errfmt.Format(err)
 	}
 	defer cancel()
 
+	var paths map[int]string
+
+	collectionID := 0
+
+	if c.Collection != "" {
+		if collectionID, err = client.ResolveCollection(ctx, c.Collection); err != nil {
+			return errfmt.Format(err)
+		}
+
+		// Names only label the diff; fall back to IDs without them.
+		if collections, err := client.Collections(ctx); err == nil {
+			paths = api.CollectionPaths(collections)
+		}
+	}
+
+	r := newRenderer(flags)
+
+	if len(ids) > 1 {
+		return c.updateAll(ctx, client, flags, ids, collectionID, paths)
+	}
+
+	u, err := c.update(ctx, client, r, ids[0], collectionID, paths)
+	if err != nil {
+		return errfmt.Format(err)
+	}
+
+	if r.Human() {
+		return nil
+	}
+
+	return r.Item(u, raindropTable(flags, updatedItems([]raindropUpdate{u})...), nil)
+}
+
+// updateAll updates several raindrops, continuing past failures. Each
+// attempt reads the raindrop again, so retrying is safe.
+func (c *UpdateCmd) updateAll(ctx context.Context, client *api.Client, flags *RootFlags, ids []int, collectionID int, paths map[int]string) error {
+	r := newRenderer(flags)
+	updates := make([]raindropUpdate, 0, len(ids))
+
+	results, runErr := bulk.Run(ctx, ids, strconv.Itoa,
+		func(ctx context.Context, batch []int) ([]int, error) {
+			u, err := c.update(ctx, client, r, batch[0], collectionID, paths)
+			if err != nil {
+				return nil, err
+			}
+
+			updates = append(updates, u)
+
+			return batch, nil
+		},
+		bulkOptions(1))
+
+	// On an interrupt, report the raindrops updated so far.
+	interrupted := errors.Is(runErr, context.Canceled)
+	if runErr != nil && !interrupted {
+		return errfmt.Format(runErr)
+	}
+
+	if !r.Human() {
+		if err := r.List(updates, raindropTable(flags, updatedItems(updates)...)); err != nil {
+			return err
+		}
+	}
+
+	summary := &bulkSummary{verb: "Updated", noun: "raindrop"}
+	summary.add(results, func(key string) string { return "ID " + key })
+	summary.unprocessed = len(ids) - len(results)
+	summary.print(summaryWriter(flags))
+
+	if interrupted {
+		return runErr
+	}
+
+	return summary.err()
+}
+
+// update writes the changes to one raindrop. In the human view, the diff is
+// shown before writing.
+func (c *UpdateCmd) update(ctx context.Context, client *api.Client, r *output.Renderer, id, collectionID int, paths map[int]string) (raindropUpdate, error) {
+	before, err := client.GetRaindrop(ctx, id)
+	if err != nil {
+		return raindropUpdate{}, err
+	}
+
+	req, changes := c.request(before, collectionID, paths)
+	u := raindropUpdate{ID: id, Changes: changes}
+
+	if len(changes) == 0 {
+		if r.Human() {
+			fmt.Fprintf(os.Stdout, "No changes: %s (ID: %d)\n", before.Title, id)
+		}
+
+		return u, nil
+	}
+
+	if r.Human() {
+		fmt.Fprintf(os.Stdout, "%s (ID: %d)\n", output.StyleBold(before.Title), id)
+		output.FormatChanges(os.Stdout, changes)
+	}
+
+	if u.Item, err = client.UpdateRaindrop(ctx, id, req); err != nil {
+		return u, err
+	}
+
+	if r.Human() {
+		fmt.Fprintf(os.Stdout, "Updated: %s (ID: %d)\n", u.Item.Title, id)
+	}
+
+	return u, nil
+}
+
+// request returns the update for the fields of r that the flags change,
+// and the changes to show.
+func (c *UpdateCmd) request(r *api.Raindrop, collectionID int, paths map[int]string) (*api.UpdateRaindropRequest, []output.FieldChange) {
 	req := &api.UpdateRaindropRequest{}
-	hasChanges := false
+	changes := make([]output.FieldChange, 0)
+
+	change := func(field string, before, after any) {
+		changes = append(changes, output.FieldChange{Field: field, Before: before, After: after})
+	}
+
+	setString := func(field string, before, after string, dst **string) {
+		if after != before {
+			*dst = &after
+			change(field, before, after)
+		}
+	}
 
 	if c.Title != "" {
-		req.Title = c.Title
-		hasChanges = true
+		setString("title", r.Title, c.Title, &req.Title)
 	}
 
-	if c.Note != "" {
-		req.Note = c.Note
-		hasChanges = true
+	if c.Link != "" {
+		setString("link", r.Link, c.Link, &req.Link)
 	}
 
-	if len(c.Tags) > 0 {
-		req.Tags = c.normalizeTags()
-		hasChanges = true
+	if c.Note != "" || c.ClearNote {
+		setString("note", r.Note, c.Note, &req.Note)
 	}
 
-	if c.Favorite {
-		v := true
-		req.Important = &v
-		hasChanges = true
+	if c.Excerpt != nil {
+		setString("excerpt", r.Excerpt, *c.Excerpt, &req.Excerpt)
 	}
 
-	if c.Unfavorite {
-		v := false
-		req.Important = &v
-		hasChanges = true
+	if c.Cover != nil {
+		setString("cover", r.Cover, *c.Cover, &req.Cover)
 	}
 
-	if c.Collection != "" {
-		collectionID, resolveErr := client.ResolveCollection(ctx, c.Collection)
-		if resolveErr != nil {
-			return errfmt.Format(resolveErr)
+	if tags := c.tags(r.Tags); !slices.Equal(tags, r.Tags) && (len(tags) > 0 || len(r.Tags) > 0) {
+		req.SetTags(tags)
+		change("tags", r.Tags, *req.Tags)
+	}
+
+	if c.Favorite || c.Unfavorite {
+		if important := c.Favorite; important != r.Important {
+			req.Important = &important
+			change("important", r.Important, important)
 		}
+	}
 
-		req.Collection = &struct {
-			ID int `json:"$id"`
-		}{ID: collectionID}
-		hasChanges = true
+	if c.Collection != "" && collectionID != r.CollectionID() {
+		req.SetCollection(collectionID)
+		change("collection", collectionLabel(r.CollectionID(), paths), collectionLabel(collectionID, paths))
 	}
 
-	if !hasChanges {
-		return fmt.Errorf("no changes specified; use --title, --tags, --note, --collection, --favorite, or --unfavorite")
+	return req, changes
+}
+
+// tags returns the tags after --clear-tags, --tags, --add-tag and
+// --remove-tag, in that order.
+func (c *UpdateCmd) tags(current []string) []string {
+	tags := current
+
+	if c.ClearTags {
+		tags = nil
 	}
 
-	raindrop, err := client.UpdateRaindrop(ctx, c.ID, req)
-	if err != nil {
-		return errfmt.Format(err)
+	if len(c.Tags) > 0 {
+		tags = splitTagFlags(c.Tags)
 	}
 
-	if flags.JSON {
-		return output.WriteJSON(os.Stdout, raindrop)
+	if len(c.AddTag) > 0 || len(c.RemoveTag) > 0 {
+		tags = api.EditTags(tags, splitTagFlags(c.AddTag), splitTagFlags(c.RemoveTag))
 	}
 
-	fmt.Fprintf(os.Stdout, "Updated: %s (ID: %d)\n", raindrop.Title, raindrop.ID)
+	return tags
+}
+
+// hasChanges reports whether any field to update was given.
+func (c *UpdateCmd) hasChanges() bool {
+	return c.Title != "" || c.Link != "" || c.Note != "" || c.ClearNote || c.Excerpt != nil || c.Cover != nil ||
+		len(c.Tags) > 0 || len(c.AddTag) > 0 || len(c.RemoveTag) > 0 || c.ClearTags ||
+		c.Favorite || c.Unfavorite || c.Collection != ""
+}
+
+// validate rejects flags that contradict each other.
+func (c *UpdateCmd) validate() error {
+	switch {
+	case c.Note != "" && c.ClearNote:
+		return errors.New("--note and --clear-note can't be combined")
+	case len(c.Tags) > 0 && c.ClearTags:
+		return errors.New("--tags and --clear-tags can't be combined")
+	case c.Favorite && c.Unfavorite:
+		return errors.New("--favorite and --unfavorite can't be combined")
+	}
 
 	return nil
 }
 
-func (c *UpdateCmd) normalizeTags() []string {
+// splitTagFlags splits comma-separated tag flag values.
+func splitTagFlags(values []string) []string {
 	var tags []string
 
-	for _, t := range c.Tags {
+	for _, t := range values {
 		for _, part := range strings.Split(t, ",") {
 			part = strings.TrimSpace(part)
 			if part != "" {
@@ -101,3 +284,16 @@ func (c *UpdateCmd) normalizeTags() []string {
 
 	return tags
 }
+
+// updatedItems returns the raindrops that were changed, for tabular output.
+func updatedItems(updates []raindropUpdate) []api.Raindrop {
+	items := make([]api.Raindrop, 0, len(updates))
+
+	for _, u := range updates {
+		if u.Item != nil {
+			items = append(items, *u.Item)
+		}
+	}
+
+	return items
+}
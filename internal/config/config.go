@@ -12,6 +12,16 @@ type File struct {
 	Timezone      string `yaml:"timezone,omitempty"`
 	OAuthPort     int    `yaml:"oauth_port,omitempty"`
 	Hyperlinks    string `yaml:"hyperlinks,omitempty"`
+	Dates         string `yaml:"dates,omitempty"`
+	CacheTTL      string `yaml:"cache_ttl,omitempty"`
+	DefaultFields string `yaml:"default_fields,omitempty"`
+	// Picker selects the fuzzy finder used when an ID is omitted: auto
+	// (fzf if installed), builtin or fzf.
+	Picker string `yaml:"picker,omitempty"`
+	// SearchesFile is an optional shared file of saved searches, e.g. one
+	// checked into a team repository.
+	SearchesFile string                 `yaml:"searches_file,omitempty"`
+	Searches     map[string]SavedSearch `yaml:"searches,omitempty"`
 }
 
 func ConfigExists() (bool, error) {
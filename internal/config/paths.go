@@ -62,6 +62,51 @@ func EnsureKeyringDir() (string, error) {
 	return dir, nil
 }
 
+func CacheDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "cache"), nil
+}
+
+// StateDir holds per-session state such as result handles.
+func StateDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state"), nil
+}
+
+func EnsureStateDir() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure state dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+func EnsureCacheDir() (string, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure cache dir: %w", err)
+	}
+
+	return dir, nil
+}
+
 // ExpandPath expands ~ at the beginning of a path to the user's home directory.
 func ExpandPath(path string) (string, error) {
 	if path == "" {
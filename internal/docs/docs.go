@@ -0,0 +1,234 @@
+// Package docs renders reference documentation (man pages and markdown) from
+// the kong command model.
+package docs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+)
+
+// Format selects the documentation output format.
+type Format string
+
+const (
+	FormatMan      Format = "man"
+	FormatMarkdown Format = "markdown"
+)
+
+// ExitCode documents a process exit status.
+type ExitCode struct {
+	Code        int
+	Description string
+}
+
+// Options configures documentation rendering.
+type Options struct {
+	Version   string
+	Date      time.Time
+	Examples  map[string][]string // keyed by command path, e.g. "collections create"
+	ExitCodes []ExitCode
+}
+
+// Commands returns the application node followed by every visible command,
+// depth first.
+func Commands(app *kong.Application) []*kong.Node {
+	var out []*kong.Node
+
+	var walk func(n *kong.Node)
+
+	walk = func(n *kong.Node) {
+		out = append(out, n)
+
+		for _, child := range n.Children {
+			if child.Type == kong.CommandNode && !child.Hidden {
+				walk(child)
+			}
+		}
+	}
+
+	walk(app.Node)
+
+	return out
+}
+
+// Generate writes one file per command to dir and returns the written paths.
+func Generate(app *kong.Application, format Format, dir string, opts Options) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gosec // docs are world-readable
+		return nil, fmt.Errorf("create docs dir: %w", err)
+	}
+
+	var written []string
+
+	for _, node := range Commands(app) {
+		var (
+			name   string
+			render func(*strings.Builder, *kong.Node, Options)
+		)
+
+		switch format {
+		case FormatMan:
+			name, render = ManFileName(node), writeMan
+		case FormatMarkdown:
+			name, render = MarkdownFileName(node), writeMarkdown
+		default:
+			return nil, fmt.Errorf("unknown docs format: %s", format)
+		}
+
+		var b strings.Builder
+
+		render(&b, node, opts)
+
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil { //nolint:gosec // docs are world-readable
+			return nil, fmt.Errorf("write %s: %w", path, err)
+		}
+
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
+// ManFileName returns the man page file name for a command, e.g.
+// "raindrop-collections-create.1".
+func ManFileName(node *kong.Node) string {
+	return strings.ReplaceAll(node.FullPath(), " ", "-") + ".1"
+}
+
+// MarkdownFileName returns the markdown file name for a command, e.g.
+// "raindrop_collections_create.md".
+func MarkdownFileName(node *kong.Node) string {
+	return strings.ReplaceAll(node.FullPath(), " ", "_") + ".md"
+}
+
+// usage returns the synopsis line for a command.
+func usage(node *kong.Node) string {
+	parts := []string{node.FullPath()}
+
+	if len(node.Flags) > 0 || node.Parent != nil {
+		parts = append(parts, "[flags]")
+	}
+
+	for _, arg := range node.Positional {
+		parts = append(parts, arg.Summary())
+	}
+
+	if len(node.Children) > 0 && len(node.Positional) == 0 {
+		parts = append(parts, "<command>")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// ownFlags returns the visible flags defined on node itself.
+func ownFlags(node *kong.Node) []*kong.Flag {
+	var out []*kong.Flag
+
+	for _, f := range node.Flags {
+		if !f.Hidden {
+			out = append(out, f)
+		}
+	}
+
+	return out
+}
+
+// inheritedFlags returns the visible flags defined on node's ancestors.
+func inheritedFlags(node *kong.Node) []*kong.Flag {
+	if node.Parent == nil {
+		return nil
+	}
+
+	var out []*kong.Flag
+
+	for _, group := range node.Parent.AllFlags(true) {
+		out = append(out, group...)
+	}
+
+	return out
+}
+
+// subcommands returns the visible direct child commands of node.
+func subcommands(node *kong.Node) []*kong.Node {
+	var out []*kong.Node
+
+	for _, child := range node.Children {
+		if child.Type == kong.CommandNode && !child.Hidden {
+			out = append(out, child)
+		}
+	}
+
+	return out
+}
+
+// flagSpec returns the flag name with short form and value placeholder,
+// e.g. "-c, --collection=COLLECTION".
+func flagSpec(f *kong.Flag) string {
+	spec := "--" + f.Name
+	if f.Short != 0 {
+		spec = fmt.Sprintf("-%c, %s", f.Short, spec)
+	}
+
+	if !f.IsBool() && !f.IsCounter() {
+		placeholder := f.PlaceHolder
+		if placeholder == "" {
+			placeholder = strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		}
+
+		spec += "=" + placeholder
+	}
+
+	return spec
+}
+
+// valueNotes describes the default and allowed values of a flag or argument.
+func valueNotes(v *kong.Value) string {
+	var notes []string
+
+	if v.Enum != "" {
+		notes = append(notes, "one of: "+strings.Join(v.EnumSlice(), ", "))
+	}
+
+	if def := shownDefault(v); def != "" {
+		notes = append(notes, "default: "+def)
+	}
+
+	if v.Required && v.Flag != nil {
+		notes = append(notes, "required")
+	}
+
+	return strings.Join(notes, "; ")
+}
+
+// helpDefault finds a default stated in help text, as in "(default: all)"
+// or "(1-50, default 50)", but not "default_output".
+var helpDefault = regexp.MustCompile(`(?i)\bdefault[: ]`)
+
+// shownDefault returns the default to document, or "" when there is none or
+// the help text already states it, often in friendlier terms such as
+// "default: all" for "0".
+func shownDefault(v *kong.Value) string {
+	if !v.HasDefault || v.IsBool() || helpDefault.MatchString(v.Help) {
+		return ""
+	}
+
+	return v.Default
+}
+
+func description(node *kong.Node) string {
+	if node.Detail != "" {
+		return node.Detail
+	}
+
+	if node.Help != "" {
+		return node.Help
+	}
+
+	return "Raindrop.io CLI - manage bookmarks from the command line"
+}
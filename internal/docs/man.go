@@ -0,0 +1,124 @@
+package docs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+func writeMan(b *strings.Builder, node *kong.Node, opts Options) {
+	title := strings.ToUpper(strings.ReplaceAll(node.FullPath(), " ", "-"))
+
+	fmt.Fprintf(b, ".TH %q \"1\" %q %q \"Raindrop CLI Manual\"\n",
+		title, opts.Date.Format("January 2006"), "raindrop "+opts.Version)
+
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(b, "%s \\- %s\n", roff(strings.ReplaceAll(node.FullPath(), " ", "-")), roff(summary(node)))
+
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(b, ".B %s\n", roff(usage(node)))
+
+	b.WriteString(".SH DESCRIPTION\n")
+	fmt.Fprintf(b, "%s\n", roff(description(node)))
+
+	if cmds := subcommands(node); len(cmds) > 0 {
+		b.WriteString(".SH COMMANDS\n")
+
+		for _, child := range cmds {
+			fmt.Fprintf(b, ".TP\n\\fB%s\\fR\n%s\nSee \\fB%s\\fR(1).\n",
+				roff(child.Name), roff(child.Help), roff(strings.TrimSuffix(ManFileName(child), ".1")))
+		}
+	}
+
+	if len(node.Positional) > 0 {
+		b.WriteString(".SH ARGUMENTS\n")
+
+		for _, arg := range node.Positional {
+			fmt.Fprintf(b, ".TP\n\\fI%s\\fR\n%s\n", roff(arg.Summary()), roff(withNotes(arg.Help, valueNotes(arg))))
+		}
+	}
+
+	writeManFlags(b, "OPTIONS", ownFlags(node))
+	writeManFlags(b, "GLOBAL OPTIONS", inheritedFlags(node))
+
+	if examples := opts.Examples[node.Path()]; len(examples) > 0 {
+		b.WriteString(".SH EXAMPLES\n.PP\n.RS\n.nf\n")
+
+		for _, ex := range examples {
+			fmt.Fprintf(b, "%s\n", roff(ex))
+		}
+
+		b.WriteString(".fi\n.RE\n")
+	}
+
+	if len(opts.ExitCodes) > 0 {
+		b.WriteString(".SH EXIT STATUS\n")
+
+		for _, ec := range opts.ExitCodes {
+			fmt.Fprintf(b, ".TP\n\\fB%d\\fR\n%s\n", ec.Code, roff(ec.Description))
+		}
+	}
+
+	var seeAlso []string
+
+	if node.Parent != nil {
+		seeAlso = append(seeAlso, fmt.Sprintf("\\fB%s\\fR(1)", roff(strings.TrimSuffix(ManFileName(node.Parent), ".1"))))
+	}
+
+	for _, child := range subcommands(node) {
+		seeAlso = append(seeAlso, fmt.Sprintf("\\fB%s\\fR(1)", roff(strings.TrimSuffix(ManFileName(child), ".1"))))
+	}
+
+	if len(seeAlso) > 0 {
+		fmt.Fprintf(b, ".SH SEE ALSO\n%s\n", strings.Join(seeAlso, ", "))
+	}
+}
+
+func writeManFlags(b *strings.Builder, heading string, flags []*kong.Flag) {
+	if len(flags) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, ".SH %s\n", heading)
+
+	for _, f := range flags {
+		fmt.Fprintf(b, ".TP\n\\fB%s\\fR\n%s\n", roff(flagSpec(f)), roff(withNotes(f.Help, valueNotes(f.Value))))
+	}
+}
+
+// roff escapes text for use in a man page.
+func roff(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		// Lines starting with a control character would be read as requests.
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func summary(node *kong.Node) string {
+	if node.Help != "" {
+		return node.Help
+	}
+
+	return description(node)
+}
+
+func withNotes(help, notes string) string {
+	if notes == "" {
+		return help
+	}
+
+	if help == "" {
+		return "(" + notes + ")"
+	}
+
+	return help + " (" + notes + ")"
+}
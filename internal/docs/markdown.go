@@ -0,0 +1,103 @@
+package docs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+func writeMarkdown(b *strings.Builder, node *kong.Node, opts Options) {
+	fmt.Fprintf(b, "# %s\n\n", node.FullPath())
+	fmt.Fprintf(b, "%s\n\n", description(node))
+
+	b.WriteString("## Usage\n\n")
+	fmt.Fprintf(b, "```\n%s\n```\n\n", usage(node))
+
+	if cmds := subcommands(node); len(cmds) > 0 {
+		b.WriteString("## Commands\n\n")
+		b.WriteString("| Command | Description |\n| --- | --- |\n")
+
+		for _, child := range cmds {
+			fmt.Fprintf(b, "| [`%s`](%s) | %s |\n", child.Name, MarkdownFileName(child), mdCell(child.Help))
+		}
+
+		b.WriteString("\n")
+	}
+
+	if len(node.Positional) > 0 {
+		b.WriteString("## Arguments\n\n")
+		b.WriteString("| Argument | Description |\n| --- | --- |\n")
+
+		for _, arg := range node.Positional {
+			fmt.Fprintf(b, "| `%s` | %s |\n", arg.Summary(), mdCell(withNotes(arg.Help, valueNotes(arg))))
+		}
+
+		b.WriteString("\n")
+	}
+
+	writeMarkdownFlags(b, "Flags", ownFlags(node))
+	writeMarkdownFlags(b, "Global flags", inheritedFlags(node))
+
+	if examples := opts.Examples[node.Path()]; len(examples) > 0 {
+		b.WriteString("## Examples\n\n```bash\n")
+
+		for _, ex := range examples {
+			fmt.Fprintf(b, "%s\n", ex)
+		}
+
+		b.WriteString("```\n\n")
+	}
+
+	if len(opts.ExitCodes) > 0 {
+		b.WriteString("## Exit codes\n\n")
+		b.WriteString("| Code | Meaning |\n| --- | --- |\n")
+
+		for _, ec := range opts.ExitCodes {
+			fmt.Fprintf(b, "| %d | %s |\n", ec.Code, mdCell(ec.Description))
+		}
+
+		b.WriteString("\n")
+	}
+
+	if node.Parent != nil {
+		fmt.Fprintf(b, "See also: [`%s`](%s)\n", node.Parent.FullPath(), MarkdownFileName(node.Parent))
+	}
+}
+
+func writeMarkdownFlags(b *strings.Builder, heading string, flags []*kong.Flag) {
+	if len(flags) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "## %s\n\n", heading)
+	b.WriteString("| Flag | Description | Default | Values |\n| --- | --- | --- | --- |\n")
+
+	for _, f := range flags {
+		def := ""
+		if d := shownDefault(f.Value); d != "" {
+			def = "`" + d + "`"
+		}
+
+		values := ""
+		if f.Enum != "" {
+			values = "`" + strings.Join(f.EnumSlice(), "`, `") + "`"
+		}
+
+		help := f.Help
+		if f.Required {
+			help += " (required)"
+		}
+
+		fmt.Fprintf(b, "| `%s` | %s | %s | %s |\n", flagSpec(f), mdCell(help), def, values)
+	}
+
+	b.WriteString("\n")
+}
+
+// mdCell escapes text for use in a markdown table cell.
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,458 @@
+// Package input reads bookmarks to create from plain URL lists, JSON Lines,
+// CSV and TSV.
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Input formats. FormatAuto detects the format from the first line.
+const (
+	FormatAuto  = "auto"
+	FormatLines = "lines"
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+	FormatTSV   = "tsv"
+)
+
+// Formats are the accepted --input-format values.
+var Formats = []string{FormatAuto, FormatLines, FormatJSONL, FormatCSV, FormatTSV}
+
+// Item is a bookmark to create. Collection is a name, path or ID, resolved
+// by the caller.
+type Item struct {
+	Line       int
+	Link       string
+	Title      string
+	Note       string
+	Tags       []string
+	Collection string
+	Important  bool
+	Created    time.Time
+}
+
+// MarshalJSON encodes the item as a JSON Lines record that Read accepts.
+func (item Item) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Link       string   `json:"link"`
+		Title      string   `json:"title,omitempty"`
+		Note       string   `json:"note,omitempty"`
+		Tags       []string `json:"tags,omitempty"`
+		Collection string   `json:"collection,omitempty"`
+		Important  bool     `json:"important,omitempty"`
+		Created    string   `json:"created,omitempty"`
+	}{item.Link, item.Title, item.Note, item.Tags, item.Collection, item.Important, ""}
+
+	if !item.Created.IsZero() {
+		out.Created = item.Created.Format(time.RFC3339)
+	}
+
+	return json.Marshal(out) //nolint:wrapcheck // plain values
+}
+
+// WriteJSONL writes items as JSON Lines, e.g. to replay failed items.
+func WriteJSONL(w io.Writer, items []Item) error {
+	enc := json.NewEncoder(w)
+
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return fmt.Errorf("write items: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// LineError is an invalid input line; the other lines are still read.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Read parses items from r. Invalid lines are returned as LineErrors; the
+// error is for unreadable input or an invalid CSV/TSV header.
+func Read(r io.Reader, format string) ([]Item, []*LineError, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read input: %w", err)
+	}
+
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	if format == FormatAuto || format == "" {
+		format = Detect(data)
+	}
+
+	switch format {
+	case FormatLines:
+		return readLines(data), nil, nil
+	case FormatJSONL:
+		items, errs := readJSONL(data)
+
+		return items, errs, nil
+	case FormatCSV:
+		return readDelimited(data, ',')
+	case FormatTSV:
+		return readDelimited(data, '\t')
+	}
+
+	return nil, nil, fmt.Errorf("invalid input format: %s (must be one of %s)", format, strings.Join(Formats, ", "))
+}
+
+// Detect guesses the format from the first non-empty line: a JSON object
+// is JSON Lines, a header naming a link or url column is CSV or TSV, and
+// anything else is a list of URLs.
+func Detect(data []byte) string {
+	line := firstLine(data)
+
+	switch {
+	case strings.HasPrefix(line, "{"):
+		return FormatJSONL
+	case strings.Contains(line, "\t") && isHeader(strings.Split(line, "\t")):
+		return FormatTSV
+	case strings.Contains(line, ",") && isHeader(strings.Split(line, ",")):
+		return FormatCSV
+	}
+
+	return FormatLines
+}
+
+func firstLine(data []byte) string {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			return line
+		}
+	}
+
+	return ""
+}
+
+func isHeader(cells []string) bool {
+	for _, c := range cells {
+		if name := normalizeColumn(c); name == "link" || name == "url" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeColumn(s string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(s), `"`))
+}
+
+// readLines reads one URL per line, skipping blank lines. Lines are passed
+// on unchecked, as before the structured formats existed; the API decides
+// what it accepts.
+func readLines(data []byte) []Item {
+	var items []Item
+
+	for i, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			items = append(items, Item{Line: i + 1, Link: line})
+		}
+	}
+
+	return items
+}
+
+// jsonItem is an item as a JSON object; "url" is an alias of "link" and
+// other keys are ignored. Tags may be an array or a comma-separated string,
+// and created a date string or Unix seconds.
+type jsonItem struct {
+	Link       string          `json:"link"`
+	URL        string          `json:"url"`
+	Title      string          `json:"title"`
+	Note       string          `json:"note"`
+	Tags       json.RawMessage `json:"tags"`
+	Collection json.RawMessage `json:"collection"`
+	Important  bool            `json:"important"`
+	Created    json.RawMessage `json:"created"`
+}
+
+func readJSONL(data []byte) ([]Item, []*LineError) {
+	var (
+		items []Item
+		errs  []*LineError
+	)
+
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		item, err := parseJSONItem(line)
+		if err == nil {
+			item.Line = i + 1
+			err = item.validate()
+		}
+
+		if err != nil {
+			errs = append(errs, &LineError{Line: i + 1, Err: err})
+
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return items, errs
+}
+
+func parseJSONItem(line string) (Item, error) {
+	var j jsonItem
+	if err := json.Unmarshal([]byte(line), &j); err != nil {
+		return Item{}, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	item := Item{Link: j.Link, Title: j.Title, Note: j.Note, Important: j.Important}
+	if item.Link == "" {
+		item.Link = j.URL
+	}
+
+	var err error
+
+	if item.Tags, err = jsonTags(j.Tags); err != nil {
+		return Item{}, err
+	}
+
+	if item.Collection, err = jsonScalar(j.Collection); err != nil {
+		return Item{}, fmt.Errorf("collection: %w", err)
+	}
+
+	created, err := jsonScalar(j.Created)
+	if err != nil {
+		return Item{}, fmt.Errorf("created: %w", err)
+	}
+
+	if item.Created, err = parseCreated(created); err != nil {
+		return Item{}, err
+	}
+
+	return item, nil
+}
+
+func jsonTags(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return splitTags(strings.Join(list, ",")), nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, errors.New("tags must be an array or a comma-separated string")
+	}
+
+	return splitTags(s), nil
+}
+
+// jsonScalar returns a JSON string or number as a string.
+func jsonScalar(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+
+	return "", errors.New("must be a string or a number")
+}
+
+func readDelimited(data []byte, comma rune) ([]Item, []*LineError, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	r.LazyQuotes = comma == '\t'
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read header: %w", err)
+	}
+
+	if !isHeader(header) {
+		return nil, nil, errors.New("the header must name a link or url column")
+	}
+
+	// Columns other than link, url, title, note, tags, collection,
+	// important and created are ignored.
+	cols := make([]string, len(header))
+	for i, h := range header {
+		cols[i] = normalizeColumn(h)
+	}
+
+	var (
+		items []Item
+		errs  []*LineError
+	)
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			errs = append(errs, &LineError{Line: parseErr.Line, Err: parseErr.Err})
+
+			continue
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("read input: %w", err)
+		}
+
+		line, _ := r.FieldPos(0)
+
+		item, err := recordItem(cols, record)
+		if err == nil {
+			item.Line = line
+			err = item.validate()
+		}
+
+		if err != nil {
+			errs = append(errs, &LineError{Line: line, Err: err})
+
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return items, errs, nil
+}
+
+func recordItem(cols, record []string) (Item, error) {
+	if len(record) > len(cols) {
+		return Item{}, fmt.Errorf("%d fields, but the header has %d", len(record), len(cols))
+	}
+
+	var item Item
+
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+
+		switch cols[i] {
+		case "link", "url":
+			if item.Link == "" {
+				item.Link = value
+			}
+		case "title":
+			item.Title = value
+		case "note":
+			item.Note = value
+		case "tags":
+			item.Tags = splitTags(value)
+		case "collection":
+			item.Collection = value
+		case "important":
+			v, err := parseBool(value)
+			if err != nil {
+				return Item{}, err
+			}
+
+			item.Important = v
+		case "created":
+			t, err := parseCreated(value)
+			if err != nil {
+				return Item{}, err
+			}
+
+			item.Created = t
+		}
+	}
+
+	return item, nil
+}
+
+func (item *Item) validate() error {
+	if item.Link == "" {
+		return errors.New("link is required")
+	}
+
+	u, err := url.Parse(item.Link)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid link: %s", item.Link)
+	}
+
+	return nil
+}
+
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "", "false", "0", "no":
+		return false, nil
+	case "true", "1", "yes":
+		return true, nil
+	}
+
+	return false, fmt.Errorf("important: invalid boolean %q", s)
+}
+
+// createdLayouts are the accepted created date formats, besides Unix
+// seconds.
+var createdLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+func parseCreated(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(secs, 0).UTC(), nil
+	}
+
+	for _, layout := range createdLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("created: invalid date %q (use RFC 3339, YYYY-MM-DD or Unix seconds)", s)
+}
+
+func splitTags(s string) []string {
+	var tags []string
+
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			tags = append(tags, part)
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// FieldChange is the before and after value of a changed raindrop field.
+// Values are strings, string slices or booleans.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// FormatChanges writes each change as removed and added lines under the
+// field name. Multi-line values are shown line by line.
+func FormatChanges(w io.Writer, changes []FieldChange) {
+	for _, c := range changes {
+		fmt.Fprintln(w, StyleBold(c.Field+":"))
+
+		for _, line := range changeLines(c.Before) {
+			fmt.Fprintln(w, StyleRed("  - "+line))
+		}
+
+		for _, line := range changeLines(c.After) {
+			fmt.Fprintln(w, StyleGreen("  + "+line))
+		}
+	}
+}
+
+func changeLines(v any) []string {
+	var s string
+
+	switch v := v.(type) {
+	case string:
+		s = v
+	case []string:
+		s = strings.Join(v, ", ")
+	case bool:
+		s = strconv.FormatBool(v)
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	if s == "" {
+		return []string{"(empty)"}
+	}
+
+	return strings.Split(s, "\n")
+}
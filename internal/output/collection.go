@@ -9,11 +9,10 @@ import (
 )
 
 // FormatCollectionRow returns table row columns for a collection.
-func FormatCollectionRow(c *api.Collection) []string {
-	parent := ""
-	if c.ParentID() != 0 {
-		parent = fmt.Sprintf("%d", c.ParentID())
-	}
+// paths maps collection IDs to full paths (see api.CollectionPaths) and is
+// used to show the parent by name.
+func FormatCollectionRow(c *api.Collection, paths map[int]string) []string {
+	parent := parentName(c, paths)
 
 	return []string{
 		fmt.Sprintf("%d", c.ID),
@@ -28,22 +27,54 @@ func CollectionTableHeaders() []string {
 	return []string{"ID", "NAME", "COUNT", "PARENT"}
 }
 
+// CollectionTable returns the tabular form of collections.
+func CollectionTable(collections []api.Collection, paths map[int]string) *Table {
+	t := &Table{Headers: CollectionTableHeaders(), Flexible: []int{1, 3}}
+
+	for i := range collections {
+		row := FormatCollectionRow(&collections[i], paths)
+		t.AddRow(row, row)
+	}
+
+	return t
+}
+
 // FormatCollectionDetail writes full collection details to w.
-func FormatCollectionDetail(w io.Writer, c *api.Collection) {
+// paths maps collection IDs to full paths and may be nil.
+func FormatCollectionDetail(w io.Writer, c *api.Collection, paths map[int]string) {
 	fmt.Fprintf(w, "%s %d\n", StyleBold("ID:"), c.ID)
 	fmt.Fprintf(w, "%s %s\n", StyleBold("Name:"), c.Title)
+
+	if path, ok := paths[c.ID]; ok && path != c.Title {
+		fmt.Fprintf(w, "%s %s\n", StyleBold("Path:"), path)
+	}
+
 	fmt.Fprintf(w, "%s %d\n", StyleBold("Count:"), c.Count)
 
 	if c.ParentID() != 0 {
-		fmt.Fprintf(w, "%s %d\n", StyleBold("Parent ID:"), c.ParentID())
+		fmt.Fprintf(w, "%s %s (ID: %d)\n", StyleBold("Parent:"), parentName(c, paths), c.ParentID())
 	}
 
 	if c.Color != "" {
 		fmt.Fprintf(w, "%s %s\n", StyleBold("Color:"), c.Color)
 	}
 
-	fmt.Fprintf(w, "%s %s\n", StyleBold("Created:"), c.Created.Format("2006-01-02 15:04"))
-	fmt.Fprintf(w, "%s %s\n", StyleBold("Updated:"), c.Updated.Format("2006-01-02 15:04"))
+	fmt.Fprintf(w, "%s %s\n", StyleBold("Created:"), formatDateDetail(c.Created))
+	fmt.Fprintf(w, "%s %s\n", StyleBold("Updated:"), formatDateDetail(c.Updated))
+}
+
+// parentName returns the parent's path, falling back to its ID when the
+// parent is unknown.
+func parentName(c *api.Collection, paths map[int]string) string {
+	if c.ParentID() == 0 {
+		return ""
+	}
+
+	if path, ok := paths[c.ParentID()]; ok {
+		return path
+	}
+
+	return fmt.Sprintf("%d", c.ParentID())
 }
 
 // CollectionTree renders collections as a tree structure.
@@ -92,10 +123,29 @@ func (t *CollectionTree) Render() {
 	}
 
 	fmt.Fprintf(t.w, "%s\n", StyleFaint("Collections:"))
-	t.renderChildren(0, "")
+
+	for _, e := range t.Entries() {
+		fmt.Fprintf(t.w, "%s%s (%d)\n", e.Prefix, e.Collection.Title, e.Collection.Count)
+	}
+}
+
+// CollectionTreeEntry is one line of a collection tree.
+type CollectionTreeEntry struct {
+	Collection api.Collection
+	// Prefix holds the connectors drawn before the title, e.g. "│   ├── ".
+	Prefix string
+}
+
+// Entries returns the custom collections in tree order.
+func (t *CollectionTree) Entries() []CollectionTreeEntry {
+	var entries []CollectionTreeEntry
+
+	t.walk(0, "", &entries)
+
+	return entries
 }
 
-func (t *CollectionTree) renderChildren(parentID int, prefix string) {
+func (t *CollectionTree) walk(parentID int, prefix string, entries *[]CollectionTreeEntry) {
 	children := t.byParent[parentID]
 
 	for i, c := range children {
@@ -106,7 +156,7 @@ func (t *CollectionTree) renderChildren(parentID int, prefix string) {
 			connector = "└── "
 		}
 
-		fmt.Fprintf(t.w, "%s%s%s (%d)\n", prefix, connector, c.Title, c.Count)
+		*entries = append(*entries, CollectionTreeEntry{Collection: c, Prefix: prefix + connector})
 
 		// Recurse for children
 		childPrefix := prefix + "│   "
@@ -114,6 +164,6 @@ func (t *CollectionTree) renderChildren(parentID int, prefix string) {
 			childPrefix = prefix + "    "
 		}
 
-		t.renderChildren(c.ID, childPrefix)
+		t.walk(c.ID, childPrefix, entries)
 	}
 }
@@ -0,0 +1,374 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dedene/raindrop-cli/internal/api"
+)
+
+// DefaultRaindropFields are the raindrop columns shown when no fields are
+// selected.
+var DefaultRaindropFields = []string{"id", "title", "url", "type", "created"}
+
+// FieldContext carries lookups needed to render raindrop fields.
+type FieldContext struct {
+	Hyperlinks HyperlinkMode
+	// Collections maps collection IDs to display names (see
+	// api.CollectionPaths). Unknown IDs render as numbers.
+	Collections map[int]string
+	// Mark, when set, highlights matched spans in table cells (see
+	// MarkMatch).
+	Mark func(string) string
+}
+
+// mark applies fc.Mark to a display cell.
+func (fc *FieldContext) mark(s string) string {
+	if fc.Mark == nil {
+		return s
+	}
+
+	return fc.Mark(s)
+}
+
+// MarkMatch is the style of matched spans in table cells.
+func MarkMatch(s string) string {
+	return StyleBold(StyleYellow(s))
+}
+
+// raindropField describes one selectable raindrop column. value is the typed
+// value used for JSON and YAML, text the plain cell used for CSV, TSV and
+// markdown, and display (optional) the terminal table cell.
+// Flexible columns may be shrunk to fit the terminal.
+type raindropField struct {
+	name     string
+	header   string
+	flexible bool
+	value    func(r *api.Raindrop, fc *FieldContext) any
+	text     func(r *api.Raindrop, fc *FieldContext) string
+	display  func(r *api.Raindrop, fc *FieldContext) string
+}
+
+const (
+	maxTitleWidth = 50
+	maxURLWidth   = 40
+)
+
+var raindropFields = []raindropField{
+	{
+		name:   "id",
+		header: "ID",
+		value:  func(r *api.Raindrop, _ *FieldContext) any { return r.ID },
+		text:   func(r *api.Raindrop, _ *FieldContext) string { return strconv.Itoa(r.ID) },
+	},
+	{
+		name:     "title",
+		flexible: true,
+		header:   "TITLE",
+		value:    func(r *api.Raindrop, _ *FieldContext) any { return r.Title },
+		text:     func(r *api.Raindrop, _ *FieldContext) string { return r.Title },
+		display: func(r *api.Raindrop, fc *FieldContext) string {
+			return fc.mark(Truncate(r.Title, maxTitleWidth))
+		},
+	},
+	{
+		name:     "url",
+		flexible: true,
+		header:   "URL",
+		value:    func(r *api.Raindrop, _ *FieldContext) any { return r.Link },
+		text:     func(r *api.Raindrop, _ *FieldContext) string { return r.Link },
+		display: func(r *api.Raindrop, fc *FieldContext) string {
+			// Truncate display but link to full URL when supported
+			return MaybeHyperlink(r.Link, fc.mark(TruncateURL(r.Link, maxURLWidth)), fc.Hyperlinks)
+		},
+	},
+	{
+		name:   "domain",
+		header: "DOMAIN",
+		value:  func(r *api.Raindrop, _ *FieldContext) any { return r.Domain },
+		text:   func(r *api.Raindrop, _ *FieldContext) string { return r.Domain },
+	},
+	{
+		name:   "type",
+		header: "TYPE",
+		value:  func(r *api.Raindrop, _ *FieldContext) any { return r.Type },
+		text:   func(r *api.Raindrop, _ *FieldContext) string { return r.Type },
+	},
+	{
+		name:     "tags",
+		flexible: true,
+		header:   "TAGS",
+		value: func(r *api.Raindrop, _ *FieldContext) any {
+			if r.Tags == nil {
+				return []string{}
+			}
+
+			return r.Tags
+		},
+		text:    func(r *api.Raindrop, _ *FieldContext) string { return strings.Join(r.Tags, ", ") },
+		display: func(r *api.Raindrop, fc *FieldContext) string { return fc.mark(strings.Join(r.Tags, ", ")) },
+	},
+	{
+		name:     "collection",
+		flexible: true,
+		header:   "COLLECTION",
+		value:    func(r *api.Raindrop, fc *FieldContext) any { return collectionName(r.CollectionID(), fc) },
+		text:     func(r *api.Raindrop, fc *FieldContext) string { return collectionName(r.CollectionID(), fc) },
+	},
+	{
+		name:   "collection_id",
+		header: "COLLECTION ID",
+		value:  func(r *api.Raindrop, _ *FieldContext) any { return r.CollectionID() },
+		text:   func(r *api.Raindrop, _ *FieldContext) string { return strconv.Itoa(r.CollectionID()) },
+	},
+	{
+		name:   "important",
+		header: "FAV",
+		value:  func(r *api.Raindrop, _ *FieldContext) any { return r.Important },
+		text:   func(r *api.Raindrop, _ *FieldContext) string { return strconv.FormatBool(r.Important) },
+		display: func(r *api.Raindrop, _ *FieldContext) string {
+			if r.Important {
+				return StyleYellow("★")
+			}
+
+			return ""
+		},
+	},
+	{
+		name:     "note",
+		flexible: true,
+		header:   "NOTE",
+		value:    func(r *api.Raindrop, _ *FieldContext) any { return r.Note },
+		text:     func(r *api.Raindrop, _ *FieldContext) string { return r.Note },
+		display: func(r *api.Raindrop, fc *FieldContext) string {
+			return fc.mark(truncateCell(r.Note, maxTitleWidth))
+		},
+	},
+	{
+		name:     "excerpt",
+		flexible: true,
+		header:   "EXCERPT",
+		value:    func(r *api.Raindrop, _ *FieldContext) any { return r.Excerpt },
+		text:     func(r *api.Raindrop, _ *FieldContext) string { return r.Excerpt },
+		display: func(r *api.Raindrop, fc *FieldContext) string {
+			return fc.mark(truncateCell(r.Excerpt, maxTitleWidth))
+		},
+	},
+	{
+		name:   "cover",
+		header: "COVER",
+		value:  func(r *api.Raindrop, _ *FieldContext) any { return r.Cover },
+		text:   func(r *api.Raindrop, _ *FieldContext) string { return r.Cover },
+	},
+	{
+		name:   "highlights",
+		header: "HIGHLIGHTS",
+		value:  func(r *api.Raindrop, _ *FieldContext) any { return len(r.Highlights) },
+		text:   func(r *api.Raindrop, _ *FieldContext) string { return strconv.Itoa(len(r.Highlights)) },
+	},
+	{
+		name:    "created",
+		header:  "CREATED",
+		value:   func(r *api.Raindrop, _ *FieldContext) any { return r.Created },
+		text:    func(r *api.Raindrop, _ *FieldContext) string { return formatDateISO(r.Created) },
+		display: func(r *api.Raindrop, _ *FieldContext) string { return formatDate(r.Created) },
+	},
+	{
+		name:    "updated",
+		header:  "UPDATED",
+		value:   func(r *api.Raindrop, _ *FieldContext) any { return r.Updated },
+		text:    func(r *api.Raindrop, _ *FieldContext) string { return formatDateISO(r.Updated) },
+		display: func(r *api.Raindrop, _ *FieldContext) string { return formatDate(r.Updated) },
+	},
+}
+
+// fieldAliases maps alternative names to canonical field names.
+var fieldAliases = map[string]string{
+	"link":       "url",
+	"favorite":   "important",
+	"fav":        "important",
+	"lastupdate": "updated",
+}
+
+// RaindropFieldNames returns all selectable raindrop field names.
+func RaindropFieldNames() []string {
+	names := make([]string, 0, len(raindropFields))
+	for _, f := range raindropFields {
+		names = append(names, f.name)
+	}
+
+	return names
+}
+
+// ParseRaindropFields parses a comma-separated field list such as
+// "id,title,tags". Names are case-insensitive; duplicates are dropped.
+func ParseRaindropFields(spec string) ([]string, error) {
+	var fields []string
+
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(spec, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			continue
+		}
+
+		if alias, ok := fieldAliases[name]; ok {
+			name = alias
+		}
+
+		if lookupRaindropField(name) == nil {
+			return nil, fmt.Errorf("unknown field: %s (valid fields: %s)", part, strings.Join(RaindropFieldNames(), ", "))
+		}
+
+		if !seen[name] {
+			seen[name] = true
+			fields = append(fields, name)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields specified (valid fields: %s)", strings.Join(RaindropFieldNames(), ", "))
+	}
+
+	return fields, nil
+}
+
+func lookupRaindropField(name string) *raindropField {
+	for i := range raindropFields {
+		if raindropFields[i].name == name {
+			return &raindropFields[i]
+		}
+	}
+
+	return nil
+}
+
+// Record is an entity reduced to selected fields. It marshals to a JSON
+// object with keys in field order.
+type Record struct {
+	Keys   []string
+	Values []any
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r Record) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, k := range r.Keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, fmt.Errorf("encode key %s: %w", k, err)
+		}
+
+		value, err := json.Marshal(r.Values[i])
+		if err != nil {
+			return nil, fmt.Errorf("encode field %s: %w", k, err)
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// RaindropRecords reduces raindrops to the given fields for structured
+// output.
+func RaindropRecords(items []api.Raindrop, fields []string, fc *FieldContext) []Record {
+	records := make([]Record, 0, len(items))
+
+	for i := range items {
+		rec := Record{Keys: fields, Values: make([]any, 0, len(fields))}
+		for _, name := range fields {
+			rec.Values = append(rec.Values, lookupRaindropField(name).value(&items[i], fc))
+		}
+
+		records = append(records, rec)
+	}
+
+	return records
+}
+
+// RaindropTable returns the tabular form of raindrops with the given fields
+// (DefaultRaindropFields when empty). Plain cells carry full values.
+func RaindropTable(items []api.Raindrop, fields []string, fc *FieldContext) *Table {
+	if len(fields) == 0 {
+		fields = DefaultRaindropFields
+	}
+
+	t := &Table{Headers: make([]string, 0, len(fields))}
+
+	for i, name := range fields {
+		f := lookupRaindropField(name)
+		t.Headers = append(t.Headers, f.header)
+
+		if f.flexible {
+			t.Flexible = append(t.Flexible, i)
+		}
+	}
+
+	for i := range items {
+		t.AddRow(FormatRaindropRow(&items[i], fields, fc), plainRaindropRow(&items[i], fields, fc))
+	}
+
+	return t
+}
+
+// FormatRaindropRow returns terminal table cells for a raindrop.
+func FormatRaindropRow(r *api.Raindrop, fields []string, fc *FieldContext) []string {
+	row := make([]string, 0, len(fields))
+
+	for _, name := range fields {
+		f := lookupRaindropField(name)
+		if f.display != nil {
+			row = append(row, f.display(r, fc))
+		} else {
+			row = append(row, f.text(r, fc))
+		}
+	}
+
+	return row
+}
+
+func plainRaindropRow(r *api.Raindrop, fields []string, fc *FieldContext) []string {
+	row := make([]string, 0, len(fields))
+	for _, name := range fields {
+		row = append(row, lookupRaindropField(name).text(r, fc))
+	}
+
+	return row
+}
+
+// collectionName returns the display name of a collection ID.
+func collectionName(id int, fc *FieldContext) string {
+	switch id {
+	case api.SystemCollectionUnsorted:
+		return "Unsorted"
+	case api.SystemCollectionTrash:
+		return "Trash"
+	}
+
+	if name, ok := fc.Collections[id]; ok {
+		return name
+	}
+
+	return strconv.Itoa(id)
+}
+
+// truncateCell collapses whitespace so multi-line text fits one row.
+func truncateCell(s string, width int) string {
+	return Truncate(strings.Join(strings.Fields(s), " "), width)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/muesli/termenv"
 )
@@ -15,8 +16,64 @@ type Mode int
 const (
 	ModeTable Mode = iota
 	ModeJSON
+	ModeNDJSON
+	ModeCSV
+	ModeTSV
+	ModeYAML
+	ModeMarkdown
 )
 
+// modeNames maps --output values to modes, in display order.
+var modeNames = []struct {
+	name string
+	mode Mode
+}{
+	{"table", ModeTable},
+	{"json", ModeJSON},
+	{"ndjson", ModeNDJSON},
+	{"csv", ModeCSV},
+	{"tsv", ModeTSV},
+	{"yaml", ModeYAML},
+	{"markdown", ModeMarkdown},
+}
+
+// ModeNames returns the accepted output mode names.
+func ModeNames() []string {
+	names := make([]string, 0, len(modeNames))
+	for _, m := range modeNames {
+		names = append(names, m.name)
+	}
+
+	return names
+}
+
+// ParseMode parses an output mode name such as "csv".
+func ParseMode(s string) (Mode, error) {
+	for _, m := range modeNames {
+		if m.name == s {
+			return m.mode, nil
+		}
+	}
+
+	return ModeTable, fmt.Errorf("invalid output mode: %s (must be one of %s)", s, strings.Join(ModeNames(), ", "))
+}
+
+func (m Mode) String() string {
+	for _, mn := range modeNames {
+		if mn.mode == m {
+			return mn.name
+		}
+	}
+
+	return "table"
+}
+
+// Structured reports whether the mode encodes values (JSON, NDJSON, YAML)
+// rather than table cells.
+func (m Mode) Structured() bool {
+	return m == ModeJSON || m == ModeNDJSON || m == ModeYAML
+}
+
 var (
 	output = termenv.NewOutput(os.Stdout)
 	// Color profiles
@@ -45,6 +102,11 @@ func StyleFaint(s string) string {
 	return output.String(s).Faint().String()
 }
 
+// StyleReverse returns a string in reverse video.
+func StyleReverse(s string) string {
+	return output.String(s).Reverse().String()
+}
+
 // StyleGreen returns a green styled string.
 func StyleGreen(s string) string {
 	return output.String(s).Foreground(colorProfile.Color("2")).String()
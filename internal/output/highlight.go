@@ -0,0 +1,36 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dedene/raindrop-cli/internal/api"
+)
+
+// HighlightTable returns the tabular form of highlights.
+func HighlightTable(highlights []api.Highlight) *Table {
+	t := &Table{Headers: []string{"ID", "TEXT", "NOTE", "COLOR"}, Flexible: []int{1, 2}}
+
+	for _, h := range highlights {
+		row := []string{h.ID, h.Text, h.Note, h.Color}
+		t.AddRow(row, row)
+	}
+
+	return t
+}
+
+// FormatHighlights writes highlights as numbered paragraphs.
+func FormatHighlights(w io.Writer, highlights []api.Highlight) {
+	for i, h := range highlights {
+		fmt.Fprintf(w, "%s %d. %s\n", StyleBold("Highlight"), i+1, h.Text)
+
+		if h.Note != "" {
+			fmt.Fprintf(w, "   %s %s\n", StyleFaint("Note:"), h.Note)
+		}
+
+		fmt.Fprintf(w, "   %s %s  %s %s\n",
+			StyleFaint("Color:"), h.Color,
+			StyleFaint("ID:"), h.ID)
+		fmt.Fprintln(w)
+	}
+}
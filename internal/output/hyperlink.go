@@ -103,15 +103,7 @@ func MaybeHyperlink(url, text string, mode HyperlinkMode) string {
 	return text
 }
 
-// TruncateURL truncates URL for display.
+// TruncateURL truncates URL for display to maxWidth cells.
 func TruncateURL(url string, maxWidth int) string {
-	if len(url) <= maxWidth {
-		return url
-	}
-
-	if maxWidth <= 3 {
-		return url[:maxWidth]
-	}
-
-	return url[:maxWidth-3] + "..."
+	return Truncate(url, maxWidth)
 }
@@ -0,0 +1,108 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/itchyny/gojq"
+)
+
+// JQ is a compiled --jq filter.
+type JQ struct {
+	code *gojq.Code
+	// Raw prints string results without JSON quoting (jq -r).
+	Raw bool
+}
+
+// ParseJQ compiles a jq expression.
+func ParseJQ(expr string, raw bool) (*JQ, error) {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("parse jq expression: %w", err)
+	}
+
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("compile jq expression: %w", err)
+	}
+
+	return &JQ{code: code, Raw: raw}, nil
+}
+
+// runEach applies the filter to every element of a slice, like jq over a
+// stream of values, or once to any other value.
+func (q *JQ) runEach(w io.Writer, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return q.run(w, v)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if err := q.run(w, rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// run applies the filter to v's JSON form and writes each result.
+func (q *JQ) run(w io.Writer, v any) error {
+	input, err := toJSONValue(v)
+	if err != nil {
+		return err
+	}
+
+	iter := q.code.Run(input)
+
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+
+		if err, isErr := result.(error); isErr {
+			var halt *gojq.HaltError
+			if errors.As(err, &halt) && halt.Value() == nil {
+				return nil
+			}
+
+			return fmt.Errorf("jq: %w", err)
+		}
+
+		if s, isString := result.(string); isString && q.Raw {
+			if _, err := fmt.Fprintln(w, s); err != nil {
+				return fmt.Errorf("write jq result: %w", err)
+			}
+
+			continue
+		}
+
+		if err := WriteJSON(w, result); err != nil {
+			return err
+		}
+	}
+}
+
+// toJSONValue converts v to the generic maps, slices and numbers gojq
+// operates on, using v's JSON encoding.
+func toJSONValue(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encode json: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var out any
+	if err := dec.Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+
+	return out, nil
+}
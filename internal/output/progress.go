@@ -0,0 +1,258 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// UnitBytes counts progress in bytes, shown as KB, MB and so on.
+const UnitBytes = "bytes"
+
+const (
+	// progressDelay keeps quick operations from flashing a bar.
+	progressDelay = 500 * time.Millisecond
+	// progressRedraw limits how often the live bar is redrawn.
+	progressRedraw = 100 * time.Millisecond
+	// ProgressInterval is the time between plain progress lines.
+	ProgressInterval = 5 * time.Second
+
+	progressBarWidth = 24
+)
+
+// Progress reports how far a long operation has come. Live progress redraws
+// a bar with the rate and an ETA on one terminal line and clears it when
+// done; plain progress prints a line every ProgressInterval, and a final
+// one if it printed any. A nil *Progress ignores all calls.
+type Progress struct {
+	mu sync.Mutex
+
+	w     io.Writer
+	label string
+	unit  string
+	live  bool
+
+	total   int64
+	current int64
+	start   time.Time
+	shown   time.Time
+	drawn   bool
+	done    bool
+}
+
+// NewProgress starts progress on w for an operation of total units; a
+// total of 0 or less is unknown. unit names what is counted, such as
+// "raindrops" or UnitBytes.
+func NewProgress(w io.Writer, label, unit string, total int64, live bool) *Progress {
+	return &Progress{w: w, label: label, unit: unit, live: live, total: max(0, total), start: time.Now()}
+}
+
+// IsTerminal reports whether w is a terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// SetTotal sets the total once it is known.
+func (p *Progress) SetTotal(total int64) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.total = max(0, total)
+	p.update(false)
+}
+
+// Set sets the number of finished units.
+func (p *Progress) Set(n int64) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current = n
+	p.update(false)
+}
+
+// Add adds n finished units.
+func (p *Progress) Add(n int64) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current += n
+	p.update(false)
+}
+
+// Done ends the progress. Further calls are ignored.
+func (p *Progress) Done() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.done {
+		return
+	}
+
+	p.update(true)
+	p.done = true
+}
+
+// Reader returns r counting what is read from it as progress.
+func (p *Progress) Reader(r io.Reader) io.Reader {
+	if p == nil {
+		return r
+	}
+
+	return &progressReader{r: r, p: p}
+}
+
+type progressReader struct {
+	r io.Reader
+	p *Progress
+}
+
+func (pr *progressReader) Read(b []byte) (int, error) {
+	n, err := pr.r.Read(b)
+	pr.p.Add(int64(n))
+
+	return n, err //nolint:wrapcheck // io.Reader contract
+}
+
+// update shows the progress if it is due; final is set by Done.
+func (p *Progress) update(final bool) {
+	if p.done {
+		return
+	}
+
+	now := time.Now()
+
+	if p.live {
+		switch {
+		case final && p.drawn:
+			fmt.Fprint(p.w, "\r\x1b[K")
+		case final, now.Sub(p.start) < progressDelay, now.Sub(p.shown) < progressRedraw:
+		default:
+			line := p.label + " " + p.bar() + " " + p.status(now)
+			if width := TerminalWidth(p.w); width > 1 {
+				line = Truncate(line, width-1)
+			}
+
+			fmt.Fprint(p.w, "\r"+line+"\x1b[K")
+
+			p.drawn, p.shown = true, now
+		}
+
+		return
+	}
+
+	switch {
+	case final && p.drawn:
+		fmt.Fprintf(p.w, "%s: %s in %s\n", p.label, p.count(), formatElapsed(now.Sub(p.start)))
+	case !final && now.Sub(p.start) >= ProgressInterval && now.Sub(p.shown) >= ProgressInterval:
+		fmt.Fprintf(p.w, "%s: %s\n", p.label, p.status(now))
+
+		p.drawn, p.shown = true, now
+	}
+}
+
+// bar returns the bar, or a spinner-like marker when the total is unknown.
+func (p *Progress) bar() string {
+	if p.total == 0 {
+		return "[" + strings.Repeat("-", progressBarWidth) + "]"
+	}
+
+	filled := int(min(p.current, p.total) * progressBarWidth / p.total)
+
+	return "[" + strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled) + "]"
+}
+
+// status returns the count, percentage, rate and ETA.
+func (p *Progress) status(now time.Time) string {
+	parts := []string{p.count()}
+
+	elapsed := now.Sub(p.start)
+
+	var rate float64
+
+	if elapsed > 0 && p.current > 0 {
+		rate = float64(p.current) / elapsed.Seconds()
+		parts = append(parts, p.amount(int64(rate))+"/s")
+	}
+
+	switch {
+	case p.total > 0 && rate > 0 && p.current < p.total:
+		eta := time.Duration(float64(p.total-p.current) / rate * float64(time.Second))
+		parts = append(parts, "ETA "+formatElapsed(eta))
+	case p.total == 0:
+		parts = append(parts, formatElapsed(elapsed))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// count returns "540/1200 raindrops (45%)", or the amount alone when the
+// total is unknown.
+func (p *Progress) count() string {
+	if p.total == 0 {
+		return p.amount(p.current)
+	}
+
+	pct := min(p.current, p.total) * 100 / p.total
+
+	if p.unit == UnitBytes {
+		return fmt.Sprintf("%s/%s (%d%%)", FormatBytes(p.current), FormatBytes(p.total), pct)
+	}
+
+	return fmt.Sprintf("%d/%d %s (%d%%)", p.current, p.total, p.unit, pct)
+}
+
+func (p *Progress) amount(n int64) string {
+	if p.unit == UnitBytes {
+		return FormatBytes(n)
+	}
+
+	return fmt.Sprintf("%d %s", n, p.unit)
+}
+
+// FormatBytes returns n as a short size such as "1.5 MB".
+func FormatBytes(n int64) string {
+	const unit = 1000
+
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "kMGTPE"[exp])
+}
+
+func formatElapsed(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(100 * time.Millisecond).String()
+	}
+
+	return d.Round(time.Second).String()
+}
@@ -8,27 +8,6 @@ import (
 	"github.com/dedene/raindrop-cli/internal/api"
 )
 
-// FormatRaindropRow returns table row columns for a raindrop.
-func FormatRaindropRow(r *api.Raindrop, hyperlinkMode HyperlinkMode) []string {
-	title := r.Title
-	if len(title) > 50 {
-		title = title[:47] + "..."
-	}
-
-	// URL column - truncate display but link to full URL when supported
-	const maxURLWidth = 40
-	displayURL := TruncateURL(r.Link, maxURLWidth)
-	urlCell := MaybeHyperlink(r.Link, displayURL, hyperlinkMode)
-
-	return []string{
-		fmt.Sprintf("%d", r.ID),
-		title,
-		urlCell,
-		r.Type,
-		r.Created.Format("2006-01-02 15:04"),
-	}
-}
-
 // FormatRaindropDetail writes full raindrop details to w.
 func FormatRaindropDetail(w io.Writer, r *api.Raindrop) {
 	fmt.Fprintf(w, "%s %d\n", StyleBold("ID:"), r.ID)
@@ -53,8 +32,8 @@ func FormatRaindropDetail(w io.Writer, r *api.Raindrop) {
 		fmt.Fprintf(w, "%s\n%s\n", StyleBold("Note:"), r.Note)
 	}
 
-	fmt.Fprintf(w, "%s %s\n", StyleBold("Created:"), r.Created.Format("2006-01-02 15:04"))
-	fmt.Fprintf(w, "%s %s\n", StyleBold("Updated:"), r.Updated.Format("2006-01-02 15:04"))
+	fmt.Fprintf(w, "%s %s\n", StyleBold("Created:"), formatDateDetail(r.Created))
+	fmt.Fprintf(w, "%s %s\n", StyleBold("Updated:"), formatDateDetail(r.Updated))
 
 	if len(r.Highlights) > 0 {
 		fmt.Fprintf(w, "\n%s\n", StyleBold("Highlights:"))
@@ -68,8 +47,3 @@ func FormatRaindropDetail(w io.Writer, r *api.Raindrop) {
 		}
 	}
 }
-
-// RaindropTableHeaders returns standard headers for raindrop list.
-func RaindropTableHeaders() []string {
-	return []string{"ID", "TITLE", "URL", "TYPE", "CREATED"}
-}
@@ -0,0 +1,290 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Table is the tabular form of a list of entities. Rows hold display cells
+// for terminal tables and may contain styles or hyperlinks; Plain holds the
+// unstyled values used by CSV, TSV and markdown. Plain defaults to Rows.
+// Flexible lists the columns, by index, that may be shrunk to fit the
+// terminal.
+type Table struct {
+	Headers  []string
+	Rows     [][]string
+	Plain    [][]string
+	Flexible []int
+}
+
+// AddRow appends a row with its display and plain cells.
+func (t *Table) AddRow(display, plain []string) {
+	t.Rows = append(t.Rows, display)
+	t.Plain = append(t.Plain, plain)
+}
+
+// Number prepends a "#" column with the 1-based row index.
+func (t *Table) Number() {
+	t.Headers = append([]string{"#"}, t.Headers...)
+
+	for i, row := range t.Rows {
+		t.Rows[i] = append([]string{StyleFaint(strconv.Itoa(i + 1))}, row...)
+	}
+
+	for i, row := range t.Plain {
+		t.Plain[i] = append([]string{strconv.Itoa(i + 1)}, row...)
+	}
+
+	for i := range t.Flexible {
+		t.Flexible[i]++
+	}
+}
+
+func (t *Table) plainRows() [][]string {
+	if t.Plain != nil {
+		return t.Plain
+	}
+
+	return t.Rows
+}
+
+// Renderer writes entities in the selected output mode. A non-nil Template
+// or JQ filter overrides Mode and is applied once per entity.
+type Renderer struct {
+	W        io.Writer
+	Mode     Mode
+	Template *template.Template
+	JQ       *JQ
+}
+
+// Human reports whether the human-readable terminal view is selected, i.e.
+// table mode without a template or jq filter.
+func (r *Renderer) Human() bool {
+	return r.Mode == ModeTable && r.Template == nil && r.JQ == nil
+}
+
+// Streaming reports whether lists can be written in chunks as they arrive,
+// because every entity is rendered independently.
+func (r *Renderer) Streaming() bool {
+	return r.Template != nil || r.JQ != nil || r.Mode == ModeNDJSON
+}
+
+// List writes a slice of entities. Structured modes encode items directly;
+// tabular modes use t.
+func (r *Renderer) List(items any, t *Table) error {
+	if r.Template != nil {
+		return executeTemplate(r.W, r.Template, items)
+	}
+
+	if r.JQ != nil {
+		return r.JQ.runEach(r.W, items)
+	}
+
+	switch r.Mode {
+	case ModeJSON:
+		return WriteJSON(r.W, items)
+	case ModeNDJSON:
+		return WriteNDJSON(r.W, items)
+	case ModeYAML:
+		return WriteYAML(r.W, items)
+	case ModeCSV:
+		return writeCSV(r.W, ',', t)
+	case ModeTSV:
+		return writeTSV(r.W, t)
+	case ModeMarkdown:
+		return writeMarkdownTable(r.W, t)
+	case ModeTable:
+	}
+
+	tw := NewTableWriter(r.W, t.Headers...)
+	tw.SetFlexible(t.Flexible...)
+
+	for _, row := range t.Rows {
+		tw.AddRow(row...)
+	}
+
+	tw.Render()
+
+	return nil
+}
+
+// Item writes a single entity. In table mode detail renders the
+// human-readable view; tabular modes write t as a one-row table.
+func (r *Renderer) Item(item any, t *Table, detail func(w io.Writer)) error {
+	if r.Template != nil {
+		return runTemplate(r.W, r.Template, item)
+	}
+
+	if r.JQ != nil {
+		return r.JQ.run(r.W, item)
+	}
+
+	switch r.Mode {
+	case ModeJSON:
+		return WriteJSON(r.W, item)
+	case ModeNDJSON:
+		return writeJSONLine(r.W, item)
+	case ModeYAML:
+		return WriteYAML(r.W, item)
+	case ModeTable:
+		if detail != nil {
+			detail(r.W)
+
+			return nil
+		}
+	case ModeCSV, ModeTSV, ModeMarkdown:
+	}
+
+	return r.List([]any{item}, t)
+}
+
+// WriteNDJSON writes each element of a slice as one compact JSON line.
+// Non-slice values are written as a single line.
+func WriteNDJSON(w io.Writer, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return writeJSONLine(w, v)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if err := writeJSONLine(w, rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeJSONLine(w io.Writer, v any) error {
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+
+	return nil
+}
+
+// WriteYAML writes v as YAML. Values go through their JSON encoding first so
+// keys and field names match the JSON output.
+func WriteYAML(w io.Writer, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("convert to yaml: %w", err)
+	}
+
+	resetYAMLStyle(&doc)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("encode yaml: %w", err)
+	}
+
+	return enc.Close() //nolint:wrapcheck // flushes the encoder
+}
+
+// resetYAMLStyle drops the JSON flow/quoted styles so the encoder emits
+// idiomatic block YAML.
+func resetYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+
+	for _, c := range n.Content {
+		resetYAMLStyle(c)
+	}
+}
+
+func writeCSV(w io.Writer, comma rune, t *Table) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	if err := cw.Write(t.Headers); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+
+	if err := cw.WriteAll(t.plainRows()); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+
+	return nil
+}
+
+// writeTSV writes tab-separated values. Tabs and newlines inside cells are
+// replaced by spaces so every record stays on one line.
+func writeTSV(w io.Writer, t *Table) error {
+	clean := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+	var buf bytes.Buffer
+
+	writeLine := func(cells []string) {
+		for i, c := range cells {
+			if i > 0 {
+				buf.WriteByte('\t')
+			}
+
+			buf.WriteString(clean.Replace(c))
+		}
+
+		buf.WriteByte('\n')
+	}
+
+	writeLine(t.Headers)
+
+	for _, row := range t.plainRows() {
+		writeLine(row)
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write tsv: %w", err)
+	}
+
+	return nil
+}
+
+func writeMarkdownTable(w io.Writer, t *Table) error {
+	clean := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+	var buf bytes.Buffer
+
+	writeLine := func(cells []string) {
+		buf.WriteString("|")
+
+		for _, c := range cells {
+			buf.WriteString(" " + clean.Replace(c) + " |")
+		}
+
+		buf.WriteByte('\n')
+	}
+
+	writeLine(t.Headers)
+
+	sep := make([]string, len(t.Headers))
+	for i := range sep {
+		sep[i] = "---"
+	}
+
+	writeLine(sep)
+
+	for _, row := range t.plainRows() {
+		writeLine(row)
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write markdown: %w", err)
+	}
+
+	return nil
+}
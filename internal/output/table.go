@@ -6,25 +6,49 @@ import (
 	"strings"
 )
 
-// TableWriter writes formatted tables.
+// columnSep separates table columns.
+const columnSep = "  "
+
+// minFlexibleWidth is the narrowest a flexible column is shrunk to.
+const minFlexibleWidth = 10
+
+// TableWriter writes formatted tables. Widths are measured in terminal cells.
+// When the table is wider than the terminal, flexible columns are shrunk and
+// their cells truncated to fit.
 type TableWriter struct {
-	w       io.Writer
-	headers []string
-	rows    [][]string
-	widths  []int
+	w        io.Writer
+	headers  []string
+	rows     [][]string
+	widths   []int
+	maxWidth int
+	flexible map[int]bool
 }
 
-// NewTableWriter creates a new table writer.
+// NewTableWriter creates a new table writer sized to w's terminal width.
 func NewTableWriter(w io.Writer, headers ...string) *TableWriter {
 	widths := make([]int, len(headers))
 	for i, h := range headers {
-		widths[i] = len(h)
+		widths[i] = StringWidth(h)
 	}
 
 	return &TableWriter{
-		w:       w,
-		headers: headers,
-		widths:  widths,
+		w:        w,
+		headers:  headers,
+		widths:   widths,
+		maxWidth: TerminalWidth(w),
+		flexible: make(map[int]bool),
+	}
+}
+
+// SetMaxWidth overrides the detected terminal width; 0 disables fitting.
+func (t *TableWriter) SetMaxWidth(width int) {
+	t.maxWidth = width
+}
+
+// SetFlexible marks columns, by index, that may be shrunk to fit.
+func (t *TableWriter) SetFlexible(cols ...int) {
+	for _, c := range cols {
+		t.flexible[c] = true
 	}
 }
 
@@ -42,10 +66,12 @@ func (t *TableWriter) AddRow(cols ...string) {
 
 // Render writes the table to the output.
 func (t *TableWriter) Render() {
+	t.fit()
+
 	// Print headers
 	for i, h := range t.headers {
 		if i > 0 {
-			fmt.Fprint(t.w, "  ")
+			fmt.Fprint(t.w, columnSep)
 		}
 
 		fmt.Fprint(t.w, StyleBold(pad(h, t.widths[i])))
@@ -57,11 +83,11 @@ func (t *TableWriter) Render() {
 	for _, row := range t.rows {
 		for i, col := range row {
 			if i > 0 {
-				fmt.Fprint(t.w, "  ")
+				fmt.Fprint(t.w, columnSep)
 			}
 
 			if i < len(t.widths) {
-				fmt.Fprint(t.w, pad(col, t.widths[i]))
+				fmt.Fprint(t.w, pad(TruncateWidth(col, t.widths[i]), t.widths[i]))
 			} else {
 				fmt.Fprint(t.w, col)
 			}
@@ -76,67 +102,45 @@ func (t *TableWriter) Count() int {
 	return len(t.rows)
 }
 
-func pad(s string, width int) string {
-	visible := VisibleWidth(s)
-	if visible >= width {
-		return s
+// fit shrinks the widest flexible column, one cell at a time, until the
+// table fits maxWidth or no flexible column can shrink further.
+func (t *TableWriter) fit() {
+	if t.maxWidth <= 0 || len(t.flexible) == 0 {
+		return
 	}
 
-	return s + strings.Repeat(" ", width-visible)
-}
-
-// VisibleWidth returns character width ignoring ANSI/OSC escape sequences.
-func VisibleWidth(s string) int {
-	width := 0
-	i := 0
-
-	for i < len(s) {
-		if s[i] == '\x1b' && i+1 < len(s) {
-			next := s[i+1]
-			if next == ']' {
-				// OSC sequence: ESC ] ... (ST or BEL)
-				// Skip until ST (\x1b\) or BEL (\x07)
-				i += 2
-
-				for i < len(s) {
-					if s[i] == '\x07' {
-						i++
-
-						break
-					}
-
-					if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
-						i += 2
-
-						break
-					}
+	total := len(columnSep) * (len(t.widths) - 1)
+	for _, w := range t.widths {
+		total += w
+	}
 
-					i++
-				}
+	for total > t.maxWidth {
+		widest := -1
 
-				continue
+		for i, w := range t.widths {
+			if t.flexible[i] && w > t.minWidth(i) && (widest < 0 || w > t.widths[widest]) {
+				widest = i
 			}
+		}
 
-			if next == '[' {
-				// CSI sequence: ESC [ ... (letter)
-				i += 2
-
-				for i < len(s) {
-					c := s[i]
-					i++
+		if widest < 0 {
+			return
+		}
 
-					if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
-						break
-					}
-				}
+		t.widths[widest]--
+		total--
+	}
+}
 
-				continue
-			}
-		}
+func (t *TableWriter) minWidth(col int) int {
+	return max(minFlexibleWidth, StringWidth(t.headers[col]))
+}
 
-		width++
-		i++
+func pad(s string, width int) string {
+	visible := VisibleWidth(s)
+	if visible >= width {
+		return s
 	}
 
-	return width
+	return s + strings.Repeat(" ", width-visible)
 }
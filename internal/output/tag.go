@@ -0,0 +1,19 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/dedene/raindrop-cli/internal/api"
+)
+
+// TagTable returns the tabular form of tags.
+func TagTable(tags []api.Tag) *Table {
+	t := &Table{Headers: []string{"TAG", "COUNT"}, Flexible: []int{0}}
+
+	for _, tag := range tags {
+		row := []string{tag.Tag, fmt.Sprintf("%d", tag.Count)}
+		t.AddRow(row, row)
+	}
+
+	return t
+}
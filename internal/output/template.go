@@ -0,0 +1,125 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ParseTemplate parses a Go template for --template output. Besides the
+// builtins it provides:
+//
+//	join SEP LIST        join list elements with SEP
+//	truncate N S         shorten S to N characters, ending in "..."
+//	date LAYOUT TIME     format TIME in the configured timezone
+//	hyperlink URL TEXT   terminal hyperlink to URL (per --hyperlinks)
+//	mdescape S           escape markdown special characters
+//	json V               compact JSON encoding of V
+//
+// The value argument comes last so helpers work in pipelines, e.g.
+// {{.Tags | join ", "}}.
+func ParseTemplate(text string, hyperlinkMode HyperlinkMode) (*template.Template, error) {
+	funcs := template.FuncMap{
+		"join":     templateJoin,
+		"truncate": templateTruncate,
+		"date": func(layout string, t time.Time) string {
+			return t.In(location).Format(layout)
+		},
+		"hyperlink": func(url, text string) string {
+			return MaybeHyperlink(url, text, hyperlinkMode)
+		},
+		"mdescape": MarkdownEscape,
+		"json":     templateJSON,
+	}
+
+	tmpl, err := template.New("output").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// executeTemplate runs tmpl once per element of a slice, or once for any
+// other value. No newline is added between items.
+func executeTemplate(w io.Writer, tmpl *template.Template, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return runTemplate(w, tmpl, v)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		// Pass pointers so pointer-receiver methods such as
+		// .CollectionID are available to the template.
+		item := rv.Index(i)
+		if item.CanAddr() {
+			item = item.Addr()
+		}
+
+		if err := runTemplate(w, tmpl, item.Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func runTemplate(w io.Writer, tmpl *template.Template, v any) error {
+	if err := tmpl.Execute(w, v); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	return nil
+}
+
+func templateJoin(sep string, list any) string {
+	rv := reflect.ValueOf(list)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Sprint(list)
+	}
+
+	parts := make([]string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		parts = append(parts, fmt.Sprint(rv.Index(i).Interface()))
+	}
+
+	return strings.Join(parts, sep)
+}
+
+func templateTruncate(n int, s string) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	if n <= 3 {
+		return string(runes[:n])
+	}
+
+	return string(runes[:n-3]) + "..."
+}
+
+func templateJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("encode json: %w", err)
+	}
+
+	return string(b), nil
+}
+
+// markdownEscaper backslash-escapes characters with meaning in markdown.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`,
+	"[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+	"#", `\#`, "|", `\|`, "<", `\<`, ">", `\>`, "!", `\!`,
+)
+
+// MarkdownEscape escapes markdown special characters in s.
+func MarkdownEscape(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,141 @@
+package output
+
+import (
+	"fmt"
+	"time"
+)
+
+// dateLayout is the layout of absolute dates.
+const dateLayout = "2006-01-02 15:04"
+
+// DateStyle selects how dates are rendered in tables and detail views.
+type DateStyle int
+
+const (
+	DateAbsolute DateStyle = iota
+	DateRelative
+	DateISO
+)
+
+// DateStyleNames are the accepted --dates values.
+var DateStyleNames = []string{"absolute", "relative", "iso"}
+
+// ParseDateStyle parses a --dates value.
+func ParseDateStyle(s string) (DateStyle, error) {
+	switch s {
+	case "absolute":
+		return DateAbsolute, nil
+	case "relative":
+		return DateRelative, nil
+	case "iso":
+		return DateISO, nil
+	}
+
+	return DateAbsolute, fmt.Errorf("invalid date style: %s (must be absolute, relative or iso)", s)
+}
+
+var (
+	// location is the timezone dates are rendered in.
+	location = time.Local
+	// dateStyle is the style for human-readable output.
+	dateStyle = DateAbsolute
+	// now is replaced in tests.
+	now = time.Now
+)
+
+// SetLocation sets the timezone used to render dates.
+func SetLocation(loc *time.Location) {
+	if loc != nil {
+		location = loc
+	}
+}
+
+// Now returns the current time in the configured timezone, for resolving
+// relative dates such as "7d".
+func Now() time.Time {
+	return now().In(location)
+}
+
+// SetDateStyle sets the style used to render dates in tables and detail
+// views. Machine formats always use ISO 8601.
+func SetDateStyle(style DateStyle) {
+	dateStyle = style
+}
+
+// formatDate renders t for tables in the configured style and timezone.
+func formatDate(t time.Time) string {
+	switch dateStyle {
+	case DateRelative:
+		return RelativeTime(t, now())
+	case DateISO:
+		return formatDateISO(t)
+	case DateAbsolute:
+	}
+
+	return t.In(location).Format(dateLayout)
+}
+
+// formatDateDetail is formatDate for detail views; relative dates also show
+// the exact time.
+func formatDateDetail(t time.Time) string {
+	if dateStyle == DateRelative {
+		return fmt.Sprintf("%s (%s)", RelativeTime(t, now()), t.In(location).Format(dateLayout))
+	}
+
+	return formatDate(t)
+}
+
+// formatDateISO renders t as RFC 3339 in the configured timezone, for
+// machine-readable formats.
+func formatDateISO(t time.Time) string {
+	return t.In(location).Format(time.RFC3339)
+}
+
+// RelativeTime describes t relative to now, e.g. "3h ago" or "2 weeks ago".
+func RelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+
+	suffix := " ago"
+	prefix := ""
+
+	if d < 0 {
+		d = -d
+		suffix, prefix = "", "in "
+	}
+
+	const (
+		day   = 24 * time.Hour
+		week  = 7 * day
+		month = 30 * day
+		year  = 365 * day
+	)
+
+	var s string
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		s = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < day:
+		s = fmt.Sprintf("%dh", int(d/time.Hour))
+	case d < week:
+		s = plural(int(d/day), "day")
+	case d < month:
+		s = plural(int(d/week), "week")
+	case d < year:
+		s = plural(int(d/month), "month")
+	default:
+		s = plural(int(d/year), "year")
+	}
+
+	return prefix + s + suffix
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelativeTime(t *testing.T) {
+	base := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{0, "just now"},
+		{59 * time.Second, "just now"},
+		{-30 * time.Second, "just now"},
+		{time.Minute, "1m ago"},
+		{59 * time.Minute, "59m ago"},
+		{time.Hour, "1h ago"},
+		{23 * time.Hour, "23h ago"},
+		{24 * time.Hour, "1 day ago"},
+		{6 * 24 * time.Hour, "6 days ago"},
+		{7 * 24 * time.Hour, "1 week ago"},
+		{29 * 24 * time.Hour, "4 weeks ago"},
+		{30 * 24 * time.Hour, "1 month ago"},
+		{364 * 24 * time.Hour, "12 months ago"},
+		{365 * 24 * time.Hour, "1 year ago"},
+		{3 * 365 * 24 * time.Hour, "3 years ago"},
+		{-5 * time.Minute, "in 5m"},
+		{-2 * 24 * time.Hour, "in 2 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := RelativeTime(base.Add(-tt.ago), base); got != tt.want {
+				t.Errorf("RelativeTime(-%s) = %q, want %q", tt.ago, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	base := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	defer func(loc *time.Location, style DateStyle, fn func() time.Time) {
+		location, dateStyle, now = loc, style, fn
+	}(location, dateStyle, now)
+
+	now = func() time.Time { return base }
+	location = tokyo
+	created := base.Add(-3 * time.Hour)
+
+	tests := []struct {
+		style  DateStyle
+		table  string
+		detail string
+	}{
+		{DateAbsolute, "2026-03-18 18:00", "2026-03-18 18:00"},
+		{DateRelative, "3h ago", "3h ago (2026-03-18 18:00)"},
+		{DateISO, "2026-03-18T18:00:00+09:00", "2026-03-18T18:00:00+09:00"},
+	}
+
+	for _, tt := range tests {
+		dateStyle = tt.style
+
+		if got := formatDate(created); got != tt.table {
+			t.Errorf("formatDate(%d) = %q, want %q", tt.style, got, tt.table)
+		}
+
+		if got := formatDateDetail(created); got != tt.detail {
+			t.Errorf("formatDateDetail(%d) = %q, want %q", tt.style, got, tt.detail)
+		}
+	}
+
+	if got := Now(); !got.Equal(base) || got.Location() != tokyo {
+		t.Errorf("Now() = %s, want %s in JST", got, base)
+	}
+}
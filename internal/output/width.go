@@ -0,0 +1,210 @@
+package output
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/term"
+)
+
+// ellipsis marks truncated text.
+const ellipsis = "..."
+
+// TerminalWidth returns the number of columns available on w: $COLUMNS if
+// set, else the terminal size if w is a terminal, else 0 (unknown).
+func TerminalWidth(w io.Writer) int {
+	if cols := os.Getenv("COLUMNS"); cols != "" {
+		if n, err := strconv.Atoi(cols); err == nil && n > 0 {
+			return n
+		}
+	}
+
+	if f, ok := w.(*os.File); ok {
+		if width, _, err := term.GetSize(int(f.Fd())); err == nil && width > 0 {
+			return width
+		}
+	}
+
+	return 0
+}
+
+// StringWidth returns the display width of s in terminal cells. East Asian
+// wide characters and most emoji count as two cells.
+func StringWidth(s string) int {
+	return uniseg.StringWidth(s)
+}
+
+// Truncate shortens s to at most width cells, ending in "..." when cut.
+// It never splits a character or grapheme cluster.
+func Truncate(s string, width int) string {
+	return TruncateWidth(strings.ToValidUTF8(s, "�"), width)
+}
+
+// TruncateWidth is Truncate for strings that may contain ANSI/OSC escape
+// sequences. Escapes are kept (so styles and hyperlinks are still closed)
+// and do not count towards the width.
+func TruncateWidth(s string, width int) string {
+	if VisibleWidth(s) <= width {
+		return s
+	}
+
+	if width <= 0 {
+		return stripText(s)
+	}
+
+	budget := width - len(ellipsis)
+	mark := ellipsis
+
+	if budget < 0 {
+		budget, mark = width, ""
+	}
+
+	var b strings.Builder
+
+	used := 0
+	cut := false
+
+	for s != "" {
+		if n := escapeLen(s); n > 0 {
+			b.WriteString(s[:n])
+			s = s[n:]
+
+			continue
+		}
+
+		cluster, rest, w, _ := uniseg.FirstGraphemeClusterInString(s, -1)
+		s = rest
+
+		if cut {
+			continue
+		}
+
+		if used+w > budget {
+			b.WriteString(mark)
+
+			cut = true
+
+			continue
+		}
+
+		b.WriteString(cluster)
+
+		used += w
+	}
+
+	return b.String()
+}
+
+// VisibleWidth returns the display width of s ignoring ANSI/OSC escape
+// sequences.
+func VisibleWidth(s string) int {
+	width := 0
+
+	for s != "" {
+		if n := escapeLen(s); n > 0 {
+			s = s[n:]
+
+			continue
+		}
+
+		_, rest, w, _ := uniseg.FirstGraphemeClusterInString(s, -1)
+		width += w
+		s = rest
+	}
+
+	return width
+}
+
+// WrapWidth splits s into lines of at most width cells, breaking between
+// grapheme clusters. Escape sequences are kept and take no space.
+func WrapWidth(s string, width int) []string {
+	if width <= 0 || VisibleWidth(s) <= width {
+		return []string{s}
+	}
+
+	var (
+		lines []string
+		b     strings.Builder
+		used  int
+	)
+
+	for s != "" {
+		if n := escapeLen(s); n > 0 {
+			b.WriteString(s[:n])
+			s = s[n:]
+
+			continue
+		}
+
+		cluster, rest, w, _ := uniseg.FirstGraphemeClusterInString(s, -1)
+		if used+w > width && used > 0 {
+			lines = append(lines, b.String())
+			b.Reset()
+
+			used = 0
+		}
+
+		b.WriteString(cluster)
+		used += w
+		s = rest
+	}
+
+	return append(lines, b.String())
+}
+
+// escapeLen returns the length of the ANSI CSI or OSC escape sequence at the
+// start of s, or 0 if s does not start with one.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' {
+		return 0
+	}
+
+	switch s[1] {
+	case ']':
+		// OSC sequence: ESC ] ... (ST or BEL)
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\x07' {
+				return i + 1
+			}
+
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+
+		return len(s)
+	case '[':
+		// CSI sequence: ESC [ ... (letter)
+		for i := 2; i < len(s); i++ {
+			if c := s[i]; (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
+				return i + 1
+			}
+		}
+
+		return len(s)
+	}
+
+	return 0
+}
+
+// stripText returns only the escape sequences of s.
+func stripText(s string) string {
+	var b strings.Builder
+
+	for s != "" {
+		if n := escapeLen(s); n > 0 {
+			b.WriteString(s[:n])
+			s = s[n:]
+
+			continue
+		}
+
+		_, rest, _, _ := uniseg.FirstGraphemeClusterInString(s, -1)
+		s = rest
+	}
+
+	return b.String()
+}
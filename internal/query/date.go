@@ -0,0 +1,133 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dayLayout = "2006-01-02"
+
+// Date is a parsed --after/--before expression: an inclusive range of
+// days. Literal dates and "N units ago" are a single day.
+type Date struct {
+	First time.Time
+	Last  time.Time
+}
+
+var (
+	agoPattern     = regexp.MustCompile(`^(\d+)([dwmy])$`)
+	quarterPattern = regexp.MustCompile(`^(\d{4})-[qQ]([1-4])$`)
+	monthPattern   = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+	yearPattern    = regexp.MustCompile(`^(\d{4})$`)
+)
+
+// ParseDate parses a date expression relative to now, in the location of
+// now:
+//
+//	2026-01-31                 a literal day
+//	7d, 2w, 3m, 1y             that many days/weeks/months/years ago
+//	today, yesterday           a single day
+//	this-week, last-week       Monday to Sunday
+//	this-month, last-month     a calendar month
+//	this-year, last-year       a calendar year
+//	2026-Q1, 2026-03, 2026     a quarter, month or year
+//
+// Ranges are inclusive: --after uses their first day and --before their
+// last, so "--after 2026-Q1 --before 2026-Q1" selects the whole quarter.
+func ParseDate(expr string, now time.Time) (Date, error) {
+	s := strings.ToLower(strings.TrimSpace(expr))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if t, err := time.ParseInLocation(dayLayout, s, now.Location()); err == nil {
+		return Date{First: t, Last: t}, nil
+	}
+
+	if m := agoPattern.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+
+		var t time.Time
+
+		switch m[2] {
+		case "d":
+			t = today.AddDate(0, 0, -n)
+		case "w":
+			t = today.AddDate(0, 0, -7*n)
+		case "m":
+			t = today.AddDate(0, -n, 0)
+		case "y":
+			t = today.AddDate(-n, 0, 0)
+		}
+
+		return Date{First: t, Last: t}, nil
+	}
+
+	if m := quarterPattern.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		q, _ := strconv.Atoi(m[2])
+		first := time.Date(year, time.Month(3*(q-1)+1), 1, 0, 0, 0, 0, now.Location())
+
+		return rangeOf(first, first.AddDate(0, 3, 0)), nil
+	}
+
+	if m := monthPattern.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+
+		if month >= 1 && month <= 12 {
+			first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, now.Location())
+
+			return rangeOf(first, first.AddDate(0, 1, 0)), nil
+		}
+	}
+
+	if m := yearPattern.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		first := time.Date(year, 1, 1, 0, 0, 0, 0, now.Location())
+
+		return rangeOf(first, first.AddDate(1, 0, 0)), nil
+	}
+
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	month := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+	year := time.Date(today.Year(), 1, 1, 0, 0, 0, 0, today.Location())
+
+	switch s {
+	case "today":
+		return rangeOf(today, today.AddDate(0, 0, 1)), nil
+	case "yesterday":
+		return rangeOf(today.AddDate(0, 0, -1), today), nil
+	case "this-week":
+		return rangeOf(monday, monday.AddDate(0, 0, 7)), nil
+	case "last-week":
+		return rangeOf(monday.AddDate(0, 0, -7), monday), nil
+	case "this-month":
+		return rangeOf(month, month.AddDate(0, 1, 0)), nil
+	case "last-month":
+		return rangeOf(month.AddDate(0, -1, 0), month), nil
+	case "this-year":
+		return rangeOf(year, year.AddDate(1, 0, 0)), nil
+	case "last-year":
+		return rangeOf(year.AddDate(-1, 0, 0), year), nil
+	}
+
+	return Date{}, fmt.Errorf("invalid date: %s (use YYYY-MM-DD, 7d, 2w, 3m, 1y, today, last-month, 2026-Q1, ...)", expr)
+}
+
+// rangeOf returns the inclusive range of days in [first, end).
+func rangeOf(first, end time.Time) Date {
+	return Date{First: first, Last: end.AddDate(0, 0, -1)}
+}
+
+// after returns the operand for "created:>" so that the range is included:
+// the API compares whole days exclusively.
+func (d Date) after() string {
+	return d.First.AddDate(0, 0, -1).Format(dayLayout)
+}
+
+// before returns the operand for "created:<" so that the range is included.
+func (d Date) before() string {
+	return d.Last.AddDate(0, 0, 1).Format(dayLayout)
+}
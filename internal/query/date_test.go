@@ -0,0 +1,77 @@
+package query
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2026, 3, 18, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		expr        string
+		first, last string
+	}{
+		{"2026-01-31", "2026-01-31", "2026-01-31"},
+		{" 2026-01-31 ", "2026-01-31", "2026-01-31"},
+		{"7d", "2026-03-11", "2026-03-11"},
+		{"2w", "2026-03-04", "2026-03-04"},
+		{"3m", "2025-12-18", "2025-12-18"},
+		{"1y", "2025-03-18", "2025-03-18"},
+		{"2026-Q1", "2026-01-01", "2026-03-31"},
+		{"2025-q4", "2025-10-01", "2025-12-31"},
+		{"2026-02", "2026-02-01", "2026-02-28"},
+		{"2024", "2024-01-01", "2024-12-31"},
+		{"today", "2026-03-18", "2026-03-18"},
+		{"yesterday", "2026-03-17", "2026-03-17"},
+		{"this-week", "2026-03-16", "2026-03-22"},
+		{"last-week", "2026-03-09", "2026-03-15"},
+		{"this-month", "2026-03-01", "2026-03-31"},
+		{"last-month", "2026-02-01", "2026-02-28"},
+		{"this-year", "2026-01-01", "2026-12-31"},
+		{"last-year", "2025-01-01", "2025-12-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			d, err := ParseDate(tt.expr, now)
+			if err != nil {
+				t.Fatalf("ParseDate(%q): %v", tt.expr, err)
+			}
+
+			if got := d.First.Format(dayLayout); got != tt.first {
+				t.Errorf("First = %s, want %s", got, tt.first)
+			}
+
+			if got := d.Last.Format(dayLayout); got != tt.last {
+				t.Errorf("Last = %s, want %s", got, tt.last)
+			}
+		})
+	}
+}
+
+func TestParseDateLocation(t *testing.T) {
+	// 23:30 UTC on the 18th is already the 19th in Tokyo.
+	tokyo := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 3, 18, 23, 30, 0, 0, time.UTC).In(tokyo)
+
+	d, err := ParseDate("today", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := d.First.Format(dayLayout); got != "2026-03-19" {
+		t.Errorf("today = %s, want 2026-03-19", got)
+	}
+}
+
+func TestParseDateInvalid(t *testing.T) {
+	now := time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC)
+
+	for _, expr := range []string{"", "soon", "7x", "2026-13", "2026-Q5", "2026-02-30", "next-week"} {
+		if _, err := ParseDate(expr, now); err == nil {
+			t.Errorf("ParseDate(%q): want error", expr)
+		}
+	}
+}
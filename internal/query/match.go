@@ -0,0 +1,155 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Case sensitivity modes for client-side matching.
+const (
+	// CaseSmart ignores case unless the pattern contains an upper-case letter.
+	CaseSmart     = "smart"
+	CaseSensitive = "sensitive"
+	CaseIgnore    = "ignore"
+)
+
+// Matcher filters bookmarks client-side, for what server search cannot do.
+// A nil Matcher matches everything.
+type Matcher struct {
+	grep *regexp.Regexp
+	url  *regexp.Regexp
+}
+
+// NewMatcher compiles a regular expression for MatchText and a glob for
+// MatchURL. Either may be empty; NewMatcher returns nil when both are.
+func NewMatcher(grep, urlGlob, caseMode string) (*Matcher, error) {
+	if grep == "" && urlGlob == "" {
+		return nil, nil //nolint:nilnil // nil matches everything
+	}
+
+	m := &Matcher{}
+
+	if grep != "" {
+		// Validate first so errors quote the pattern as given.
+		if _, err := regexp.Compile(grep); err != nil {
+			return nil, fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+
+		m.grep = regexp.MustCompile(casePrefix(grep, caseMode) + grep)
+	}
+
+	if urlGlob != "" {
+		m.url = regexp.MustCompile(casePrefix(urlGlob, caseMode) + globToRegexp(urlGlob))
+	}
+
+	return m, nil
+}
+
+// MatchURL reports whether link matches the URL glob. The glob is tried
+// against the full link and without its scheme and "www." prefix.
+func (m *Matcher) MatchURL(link string) bool {
+	if m == nil || m.url == nil {
+		return true
+	}
+
+	return m.url.MatchString(link) || m.url.MatchString(stripScheme(link))
+}
+
+// MatchText reports whether any of the fields matches the grep pattern.
+func (m *Matcher) MatchText(fields ...string) bool {
+	if m == nil || m.grep == nil {
+		return true
+	}
+
+	for _, s := range fields {
+		if m.grep.MatchString(s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Mark applies style to each span of s matched by the grep pattern.
+func (m *Matcher) Mark(s string, style func(string) string) string {
+	if m == nil || m.grep == nil {
+		return s
+	}
+
+	spans := m.grep.FindAllStringIndex(s, -1)
+	if len(spans) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+
+	last := 0
+
+	for _, span := range spans {
+		if span[0] == span[1] {
+			continue
+		}
+
+		b.WriteString(s[last:span[0]])
+		b.WriteString(style(s[span[0]:span[1]]))
+		last = span[1]
+	}
+
+	b.WriteString(s[last:])
+
+	return b.String()
+}
+
+// casePrefix returns the regexp flag that makes pattern case-insensitive
+// under mode, if any.
+func casePrefix(pattern, mode string) string {
+	switch mode {
+	case CaseSensitive:
+		return ""
+	case CaseIgnore:
+		return "(?i)"
+	}
+
+	for _, r := range pattern {
+		if unicode.IsUpper(r) {
+			return ""
+		}
+	}
+
+	return "(?i)"
+}
+
+// globToRegexp translates a glob into an anchored regular expression. Unlike
+// path globs, * also matches "/" so "github.com/org/*" covers nested paths.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+
+	b.WriteString("^")
+
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	b.WriteString("$")
+
+	return b.String()
+}
+
+// stripScheme removes the scheme and a leading "www." from a URL, so globs
+// can be written as "github.com/...".
+func stripScheme(link string) string {
+	if _, rest, ok := strings.Cut(link, "://"); ok {
+		link = rest
+	}
+
+	return strings.TrimPrefix(link, "www.")
+}
@@ -0,0 +1,164 @@
+// Package query compiles structured bookmark filters into Raindrop.io search
+// operators.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Filter describes a bookmark search. The zero value matches everything.
+type Filter struct {
+	Text          string   `json:"text,omitempty" yaml:"text,omitempty"`
+	Tags          []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	AnyTag        bool     `json:"any_tag,omitempty" yaml:"any_tag,omitempty"`
+	ExcludeTags   []string `json:"exclude_tags,omitempty" yaml:"exclude_tags,omitempty"`
+	Type          string   `json:"type,omitempty" yaml:"type,omitempty"`
+	Domain        string   `json:"domain,omitempty" yaml:"domain,omitempty"`
+	InTitle       string   `json:"in_title,omitempty" yaml:"in_title,omitempty"`
+	InNote        string   `json:"in_note,omitempty" yaml:"in_note,omitempty"`
+	Favorites     bool     `json:"favorites,omitempty" yaml:"favorites,omitempty"`
+	Untagged      bool     `json:"untagged,omitempty" yaml:"untagged,omitempty"`
+	HasNote       bool     `json:"has_note,omitempty" yaml:"has_note,omitempty"`
+	HasHighlights bool     `json:"has_highlights,omitempty" yaml:"has_highlights,omitempty"`
+	Broken        bool     `json:"broken,omitempty" yaml:"broken,omitempty"`
+	Duplicates    bool     `json:"duplicates,omitempty" yaml:"duplicates,omitempty"`
+	// After and Before accept the expressions understood by ParseDate.
+	After  string `json:"after,omitempty" yaml:"after,omitempty"`
+	Before string `json:"before,omitempty" yaml:"before,omitempty"`
+}
+
+// errAnyTagCombined rejects --match any with other conditions: the API
+// applies match:OR to the whole query, not just to the tags.
+var errAnyTagCombined = errors.New("--match any can't be combined with text or other filters: Raindrop would match any of them, not just any tag")
+
+// Build compiles the filter into a search string. Relative dates are
+// resolved against now, in its location.
+func (f *Filter) Build(now time.Time) (string, error) {
+	var parts []string
+
+	if text := strings.TrimSpace(f.Text); text != "" {
+		parts = append(parts, text)
+	}
+
+	tags := 0
+
+	for _, tag := range f.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			parts = append(parts, "#"+quote(tag))
+			tags++
+		}
+	}
+
+	for _, tag := range f.ExcludeTags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			parts = append(parts, "-#"+quote(tag))
+		}
+	}
+
+	if f.Type != "" {
+		parts = append(parts, "type:"+f.Type)
+	}
+
+	if f.Domain != "" {
+		parts = append(parts, "link:"+quote(f.Domain))
+	}
+
+	if f.InTitle != "" {
+		parts = append(parts, "title:"+quote(f.InTitle))
+	}
+
+	if f.InNote != "" {
+		parts = append(parts, "note:"+quote(f.InNote))
+	}
+
+	flags := []struct {
+		set      bool
+		operator string
+	}{
+		{f.Favorites, "important:true"},
+		{f.Untagged, "notag:true"},
+		{f.HasNote, "note:true"},
+		{f.HasHighlights, "highlights:true"},
+		{f.Broken, "broken:true"},
+		{f.Duplicates, "duplicate:true"},
+	}
+
+	for _, fl := range flags {
+		if fl.set {
+			parts = append(parts, fl.operator)
+		}
+	}
+
+	if f.After != "" {
+		d, err := ParseDate(f.After, now)
+		if err != nil {
+			return "", fmt.Errorf("--after: %w", err)
+		}
+
+		parts = append(parts, "created:>"+d.after())
+	}
+
+	if f.Before != "" {
+		d, err := ParseDate(f.Before, now)
+		if err != nil {
+			return "", fmt.Errorf("--before: %w", err)
+		}
+
+		parts = append(parts, "created:<"+d.before())
+	}
+
+	if f.AnyTag && tags > 1 {
+		if len(parts) > tags {
+			return "", errAnyTagCombined
+		}
+
+		parts = append(parts, "match:OR")
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// quote wraps values containing spaces in double quotes.
+func quote(s string) string {
+	if strings.ContainsAny(s, " \t") {
+		return `"` + strings.ReplaceAll(s, `"`, "") + `"`
+	}
+
+	return s
+}
+
+// Merge returns f with the conditions of o added. Tags are appended; other
+// fields set in o override those in f.
+func (f Filter) Merge(o Filter) Filter {
+	f.Tags = append(append([]string(nil), f.Tags...), o.Tags...)
+	f.ExcludeTags = append(append([]string(nil), f.ExcludeTags...), o.ExcludeTags...)
+	f.AnyTag = f.AnyTag || o.AnyTag
+	f.Favorites = f.Favorites || o.Favorites
+	f.Untagged = f.Untagged || o.Untagged
+	f.HasNote = f.HasNote || o.HasNote
+	f.HasHighlights = f.HasHighlights || o.HasHighlights
+	f.Broken = f.Broken || o.Broken
+	f.Duplicates = f.Duplicates || o.Duplicates
+
+	for _, s := range []struct {
+		dst *string
+		src string
+	}{
+		{&f.Text, o.Text},
+		{&f.Type, o.Type},
+		{&f.Domain, o.Domain},
+		{&f.InTitle, o.InTitle},
+		{&f.InNote, o.InNote},
+		{&f.After, o.After},
+		{&f.Before, o.Before},
+	} {
+		if s.src != "" {
+			*s.dst = s.src
+		}
+	}
+
+	return f
+}
@@ -0,0 +1,78 @@
+package query
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFilterBuild(t *testing.T) {
+	now := time.Date(2026, 3, 18, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   string
+	}{
+		{"empty", Filter{}, ""},
+		{"text", Filter{Text: "  kubernetes  "}, "kubernetes"},
+		{"tags", Filter{Tags: []string{"go", " ", "dev ops"}}, `#go #"dev ops"`},
+		{"any tag", Filter{Tags: []string{"go", "rust"}, AnyTag: true}, "#go #rust match:OR"},
+		{"any single tag", Filter{Tags: []string{"go"}, AnyTag: true}, "#go"},
+		{"exclude tags", Filter{ExcludeTags: []string{"archived"}}, "-#archived"},
+		{"fields", Filter{Type: "article", Domain: "github.com", InTitle: "go tips", InNote: "todo"}, `type:article link:github.com title:"go tips" note:todo`},
+		{
+			"flags",
+			Filter{Favorites: true, Untagged: true, HasNote: true, HasHighlights: true, Broken: true, Duplicates: true},
+			"important:true notag:true note:true highlights:true broken:true duplicate:true",
+		},
+		{"exact day", Filter{After: "2026-01-31", Before: "2026-01-31"}, "created:>2026-01-30 created:<2026-02-01"},
+		{"days ago", Filter{After: "7d"}, "created:>2026-03-10"},
+		{"month", Filter{After: "2026-02", Before: "2026-02"}, "created:>2026-01-31 created:<2026-03-01"},
+		{"combined", Filter{Text: "go", Tags: []string{"dev"}, Favorites: true, After: "last-week"}, "go #dev important:true created:>2026-03-08"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.Build(now)
+			if err != nil {
+				t.Fatalf("Build: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Build = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterBuildErrors(t *testing.T) {
+	now := time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC)
+	anyTags := []string{"go", "rust"}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   error
+	}{
+		{"invalid after", Filter{After: "soon"}, nil},
+		{"invalid before", Filter{Before: "2026-13"}, nil},
+		{"any tag with text", Filter{Text: "tips", Tags: anyTags, AnyTag: true}, errAnyTagCombined},
+		{"any tag with exclude", Filter{Tags: anyTags, AnyTag: true, ExcludeTags: []string{"old"}}, errAnyTagCombined},
+		{"any tag with flag", Filter{Tags: anyTags, AnyTag: true, Favorites: true}, errAnyTagCombined},
+		{"any tag with date", Filter{Tags: anyTags, AnyTag: true, After: "7d"}, errAnyTagCombined},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.filter.Build(now)
+			if err == nil {
+				t.Fatal("Build: want error")
+			}
+
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Build error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Package state stores per-terminal-session state between invocations.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dedene/raindrop-cli/internal/config"
+)
+
+// SessionEnv overrides the terminal session key, e.g. to share handles
+// between shells.
+const SessionEnv = "RAINDROP_SESSION"
+
+// maxAge is how long handle files of other sessions are kept.
+const maxAge = 7 * 24 * time.Hour
+
+const handlesPrefix = "handles-"
+
+// Handles are the ordered raindrop IDs of the last listing in a session.
+type Handles struct {
+	IDs     []int     `json:"ids"`
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// SaveHandles records ids as the last listing of this session for account
+// and prunes the files of old sessions.
+func SaveHandles(account string, ids []int) error {
+	dir, err := config.EnsureStateDir()
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(Handles{IDs: ids, SavedAt: time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("encode handles: %w", err)
+	}
+
+	path := filepath.Join(dir, handlesFile(account))
+	tmp := path + ".tmp"
+
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("write handles: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("commit handles: %w", err)
+	}
+
+	prune(dir)
+
+	return nil
+}
+
+// LoadHandles returns the last listing of this session for account; ok is
+// false when there is none.
+func LoadHandles(account string) (Handles, bool, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return Handles{}, false, err
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, handlesFile(account)))
+	if errors.Is(err, os.ErrNotExist) {
+		return Handles{}, false, nil
+	}
+
+	if err != nil {
+		return Handles{}, false, fmt.Errorf("read handles: %w", err)
+	}
+
+	var h Handles
+	if err := json.Unmarshal(b, &h); err != nil {
+		return Handles{}, false, fmt.Errorf("parse handles: %w", err)
+	}
+
+	return h, true, nil
+}
+
+// Clear removes the handles of all sessions, e.g. after switching accounts.
+// Other files in the state directory, such as bulk checkpoints, are kept.
+func Clear() error {
+	dir, err := config.StateDir()
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("clear handles: %w", err)
+	}
+
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), handlesPrefix) {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(dir, e.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("clear handles: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// handlesFile names the handles file of this session and account, so that
+// handles never refer to another account's listing.
+func handlesFile(account string) string {
+	return handlesPrefix + session() + "-" + sanitize(account) + ".json"
+}
+
+// session identifies the terminal session: $RAINDROP_SESSION, or else the
+// parent process, which is the interactive shell.
+func session() string {
+	if s := os.Getenv(SessionEnv); s != "" {
+		return sanitize(s)
+	}
+
+	return "ppid-" + strconv.Itoa(os.Getppid())
+}
+
+// sanitize makes s safe as part of a file name.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+
+		return r
+	}, s)
+}
+
+// prune removes handle files not written for maxAge.
+func prune(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), handlesPrefix) {
+			continue
+		}
+
+		if info, err := e.Info(); err == nil && time.Since(info.ModTime()) > maxAge {
+			_ = os.Remove(filepath.Join(dir, e.Name()))
+		}
+	}
+}
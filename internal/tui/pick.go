@@ -0,0 +1,288 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dedene/raindrop-cli/internal/output"
+)
+
+// ErrCancelled is returned by Pick when the user leaves without choosing.
+var ErrCancelled = errors.New("cancelled")
+
+// PickItem is a candidate of the fuzzy finder.
+type PickItem struct {
+	ID int
+	// Label is the displayed line; Text is matched against the query.
+	Label string
+	Text  string
+}
+
+// PickOptions configures Pick.
+type PickOptions struct {
+	Prompt string
+	// Multi allows selecting several items with Tab.
+	Multi bool
+	// Search, when set, replaces the candidates with server results for
+	// the query (Ctrl-S, or Enter when nothing matches).
+	Search func(query string) ([]PickItem, error)
+}
+
+// picker is the state of the fuzzy finder.
+type picker struct {
+	opts     PickOptions
+	items    []PickItem
+	query    []rune
+	matches  []int
+	cursor   int
+	offset   int
+	selected map[int]bool
+	status   string
+}
+
+// Pick shows a fuzzy finder over items on stderr and returns the IDs of
+// the chosen items.
+func Pick(items []PickItem, opts PickOptions) ([]int, error) {
+	t, err := openTerminal(os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	defer t.close()
+
+	p := &picker{opts: opts, items: items, selected: make(map[int]bool)}
+	p.match()
+
+	for {
+		width, height := t.size()
+		if err := t.draw(p.render(width, height)); err != nil {
+			return nil, err
+		}
+
+		keys, err := t.read()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, k := range keys {
+			if ids, done, err := p.handleKey(k, height); done {
+				return ids, err
+			}
+		}
+	}
+}
+
+// handleKey applies a key; done reports that the finder should close.
+func (p *picker) handleKey(k key, height int) ([]int, bool, error) {
+	switch k.code {
+	case keyCtrlC, keyEsc:
+		return nil, true, ErrCancelled
+	case keyEnter:
+		if len(p.matches) == 0 && len(p.query) > 0 && p.opts.Search != nil {
+			p.search()
+
+			return nil, false, nil
+		}
+
+		return p.chosen(), len(p.chosen()) > 0, nil
+	case keyUp, keyCtrlP:
+		p.cursor = clamp(p.cursor-1, 0, len(p.matches)-1)
+	case keyDown, keyCtrlN:
+		p.cursor = clamp(p.cursor+1, 0, len(p.matches)-1)
+	case keyPageUp:
+		p.cursor = clamp(p.cursor-(height-2), 0, len(p.matches)-1)
+	case keyPageDown:
+		p.cursor = clamp(p.cursor+(height-2), 0, len(p.matches)-1)
+	case keyTab:
+		if p.opts.Multi && len(p.matches) > 0 {
+			id := p.items[p.matches[p.cursor]].ID
+			if p.selected[id] {
+				delete(p.selected, id)
+			} else {
+				p.selected[id] = true
+			}
+
+			p.cursor = clamp(p.cursor+1, 0, len(p.matches)-1)
+		}
+	case keyCtrlS:
+		if len(p.query) > 0 && p.opts.Search != nil {
+			p.search()
+		}
+	case keyBackspace:
+		if len(p.query) > 0 {
+			p.query = p.query[:len(p.query)-1]
+			p.match()
+		}
+	case keyCtrlU:
+		p.query = p.query[:0]
+		p.match()
+	case keyRune:
+		p.query = append(p.query, k.r)
+		p.match()
+	}
+
+	return nil, false, nil
+}
+
+// chosen returns the selected IDs, or the item under the cursor.
+func (p *picker) chosen() []int {
+	var ids []int
+
+	for _, item := range p.items {
+		if p.selected[item.ID] {
+			ids = append(ids, item.ID)
+		}
+	}
+
+	if len(ids) == 0 && len(p.matches) > 0 {
+		ids = append(ids, p.items[p.matches[p.cursor]].ID)
+	}
+
+	return ids
+}
+
+func (p *picker) search() {
+	q := string(p.query)
+
+	items, err := p.opts.Search(q)
+	if err != nil {
+		msg, _, _ := strings.Cut(err.Error(), "\n")
+		p.status = output.StyleRed(msg)
+
+		return
+	}
+
+	p.items, p.query = items, p.query[:0]
+	p.status = fmt.Sprintf("search: %s", q)
+	p.match()
+}
+
+// match ranks the items against the query; ties keep their order.
+func (p *picker) match() {
+	type scored struct{ index, score int }
+
+	var ranked []scored
+
+	for i, item := range p.items {
+		if score, ok := fuzzyScore(string(p.query), item.Text); ok {
+			ranked = append(ranked, scored{i, score})
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+
+	p.matches = p.matches[:0]
+	for _, r := range ranked {
+		p.matches = append(p.matches, r.index)
+	}
+
+	p.cursor, p.offset = 0, 0
+}
+
+func (p *picker) render(width, height int) []string {
+	lines := make([]string, 0, height)
+
+	info := fmt.Sprintf("%d/%d", len(p.matches), len(p.items))
+	if n := len(p.selected); n > 0 {
+		info += fmt.Sprintf(" (%d selected)", n)
+	}
+
+	if p.status != "" {
+		info += "  " + p.status
+	}
+
+	lines = append(lines, pad(output.StyleBold(p.opts.Prompt)+string(p.query)+"█", width))
+	lines = append(lines, pad(output.StyleFaint("  "+info+"  "+p.hint()), width))
+
+	rows := max(1, height-len(lines))
+	p.offset = scrollOffset(p.cursor, p.offset, rows, len(p.matches))
+
+	for i := p.offset; i < len(p.matches) && len(lines) < height; i++ {
+		item := p.items[p.matches[i]]
+
+		mark := "  "
+		if p.selected[item.ID] {
+			mark = output.StyleGreen("● ")
+		}
+
+		if i == p.cursor {
+			lines = append(lines, mark+output.StyleReverse(pad(item.Label, width-2)))
+		} else {
+			lines = append(lines, mark+pad(item.Label, width-2))
+		}
+	}
+
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+
+	return lines
+}
+
+func (p *picker) hint() string {
+	hint := "Enter choose, Esc cancel"
+	if p.opts.Multi {
+		hint = "Tab select, " + hint
+	}
+
+	if p.opts.Search != nil {
+		hint += ", Ctrl-S search Raindrop"
+	}
+
+	return hint
+}
+
+// fuzzyScore matches each space-separated term of query as a subsequence of
+// text, ignoring case. Consecutive characters and word starts score higher.
+func fuzzyScore(query, text string) (int, bool) {
+	text = strings.ToLower(text)
+	total := 0
+
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		score, ok := subsequenceScore([]rune(term), []rune(text))
+		if !ok {
+			return 0, false
+		}
+
+		total += score
+	}
+
+	return total, true
+}
+
+func subsequenceScore(pattern, text []rune) (int, bool) {
+	score, pi := 0, 0
+	prevMatch := false
+
+	for i, r := range text {
+		if pi == len(pattern) {
+			break
+		}
+
+		if r != pattern[pi] {
+			prevMatch = false
+
+			continue
+		}
+
+		score++
+
+		if prevMatch {
+			score += 5
+		}
+
+		if i == 0 || !unicode.IsLetter(text[i-1]) && !unicode.IsDigit(text[i-1]) {
+			score += 3
+		}
+
+		prevMatch = true
+		pi++
+	}
+
+	return score, pi == len(pattern)
+}
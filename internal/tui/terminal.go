@@ -0,0 +1,217 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// Key names reported by readKeys. Printable input is keyRune.
+const (
+	keyRune = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyEsc
+	keyTab
+	keyBackspace
+	keyDelete
+	keyCtrlC
+	keyCtrlD
+	keyCtrlN
+	keyCtrlP
+	keyCtrlS
+	keyCtrlU
+)
+
+// key is a decoded key press.
+type key struct {
+	code int
+	r    rune
+}
+
+// terminal is the raw-mode alternate screen the UI draws on.
+type terminal struct {
+	in     *os.File
+	screen *os.File
+	out    *bufio.Writer
+	state  *term.State
+}
+
+// openTerminal switches to the alternate screen on out, reading keys from
+// stdin.
+func openTerminal(out *os.File) (*terminal, error) {
+	if !IsTerminal(out) {
+		return nil, ErrNotTerminal
+	}
+
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return nil, fmt.Errorf("enter raw mode: %w", err)
+	}
+
+	t := &terminal{in: os.Stdin, screen: out, out: bufio.NewWriter(out), state: state}
+
+	// Alternate screen, hidden cursor.
+	t.out.WriteString("\x1b[?1049h\x1b[?25l")
+
+	return t, t.out.Flush()
+}
+
+// close restores the screen and terminal mode.
+func (t *terminal) close() {
+	t.out.WriteString("\x1b[?25h\x1b[?1049l")
+	_ = t.out.Flush()
+	_ = term.Restore(int(t.in.Fd()), t.state)
+}
+
+// size returns the terminal size, with a usable fallback.
+func (t *terminal) size() (int, int) {
+	w, h, err := term.GetSize(int(t.screen.Fd()))
+	if err != nil || w <= 0 || h <= 0 {
+		return 80, 24
+	}
+
+	return w, h
+}
+
+// draw replaces the screen with lines.
+func (t *terminal) draw(lines []string) error {
+	for i, line := range lines {
+		fmt.Fprintf(t.out, "\x1b[%d;1H%s\x1b[0m\x1b[K", i+1, line)
+	}
+
+	return t.out.Flush()
+}
+
+// IsTerminal reports whether stdin and out are both terminals.
+func IsTerminal(out *os.File) bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(out.Fd()))
+}
+
+// read blocks for the next key presses.
+func (t *terminal) read() ([]key, error) {
+	buf := make([]byte, 256)
+
+	n, err := t.in.Read(buf)
+	if err != nil {
+		return nil, fmt.Errorf("read terminal: %w", err)
+	}
+
+	return parseKeys(buf[:n]), nil
+}
+
+// readKeys sends key presses to keys until reading fails.
+func (t *terminal) readKeys(keys chan<- key) {
+	for {
+		ks, err := t.read()
+		if err != nil {
+			close(keys)
+
+			return
+		}
+
+		for _, k := range ks {
+			keys <- k
+		}
+	}
+}
+
+// escapeKeys maps escape sequences (without the leading ESC) to keys.
+var escapeKeys = map[string]int{
+	"[A": keyUp, "OA": keyUp,
+	"[B": keyDown, "OB": keyDown,
+	"[C": keyRight, "OC": keyRight,
+	"[D": keyLeft, "OD": keyLeft,
+	"[H": keyHome, "OH": keyHome, "[1~": keyHome, "[7~": keyHome,
+	"[F": keyEnd, "OF": keyEnd, "[4~": keyEnd, "[8~": keyEnd,
+	"[5~": keyPageUp,
+	"[6~": keyPageDown,
+	"[3~": keyDelete,
+}
+
+// parseKeys decodes one read from the terminal. A lone ESC is the Escape
+// key; unknown escape sequences are dropped.
+func parseKeys(b []byte) []key {
+	var keys []key
+
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b:
+			if len(b) == 1 {
+				return append(keys, key{code: keyEsc})
+			}
+
+			n := escapeSeqLen(b)
+			if code, ok := escapeKeys[string(b[1:n])]; ok {
+				keys = append(keys, key{code: code})
+			}
+
+			b = b[n:]
+
+			continue
+		case c == '\r' || c == '\n':
+			keys = append(keys, key{code: keyEnter})
+		case c == '\t':
+			keys = append(keys, key{code: keyTab})
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, key{code: keyBackspace})
+		case c == 0x03:
+			keys = append(keys, key{code: keyCtrlC})
+		case c == 0x04:
+			keys = append(keys, key{code: keyCtrlD})
+		case c == 0x0e:
+			keys = append(keys, key{code: keyCtrlN})
+		case c == 0x10:
+			keys = append(keys, key{code: keyCtrlP})
+		case c == 0x13:
+			keys = append(keys, key{code: keyCtrlS})
+		case c == 0x15:
+			keys = append(keys, key{code: keyCtrlU})
+		case c < 0x20:
+			// Other control keys are ignored.
+		default:
+			r, size := utf8.DecodeRune(b)
+			keys = append(keys, key{code: keyRune, r: r})
+			b = b[size:]
+
+			continue
+		}
+
+		b = b[1:]
+	}
+
+	return keys
+}
+
+// escapeSeqLen returns the length of the escape sequence at the start of b.
+func escapeSeqLen(b []byte) int {
+	if len(b) < 2 {
+		return len(b)
+	}
+
+	switch b[1] {
+	case '[':
+		for i := 2; i < len(b); i++ {
+			if b[i] >= 0x40 && b[i] <= 0x7e {
+				return i + 1
+			}
+		}
+
+		return len(b)
+	case 'O':
+		return min(3, len(b))
+	}
+
+	// Alt+key: ESC followed by the key.
+	return 2
+}
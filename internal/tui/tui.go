@@ -0,0 +1,768 @@
+// Package tui implements the interactive bookmark browser (raindrop tui).
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dedene/raindrop-cli/internal/api"
+	"github.com/dedene/raindrop-cli/internal/output"
+)
+
+// ErrNotTerminal is returned when stdin or stdout is not a terminal.
+var ErrNotTerminal = errors.New("the interactive UI needs a terminal")
+
+// requestTimeout bounds each API call made from the UI.
+const requestTimeout = 30 * time.Second
+
+// resizePoll is how often the terminal size is checked.
+const resizePoll = 250 * time.Millisecond
+
+// Options configures Run.
+type Options struct {
+	Client *api.Client
+	// CollectionID is the collection shown first (0: all raindrops).
+	CollectionID int
+	// Search is the initial Raindrop search query.
+	Search string
+	// Open opens a URL in the browser; Copy puts text on the clipboard.
+	Open func(url string) error
+	Copy func(text string) error
+	// FormatError turns API errors into user-facing messages.
+	FormatError func(error) error
+}
+
+type pane int
+
+const (
+	paneCollections pane = iota
+	paneList
+)
+
+type mode int
+
+const (
+	modeNormal mode = iota
+	modeFilter
+	modePrompt
+	modeConfirm
+	modeHelp
+)
+
+// collectionEntry is one line of the collections pane.
+type collectionEntry struct {
+	id    int
+	name  string
+	label string
+	count int
+}
+
+// model is the UI state.
+type model struct {
+	ctx  context.Context
+	opts Options
+
+	collections []collectionEntry
+	colCursor   int
+	colOffset   int
+
+	collectionID int
+	search       string
+	items        []api.Raindrop
+	total        int
+	page         int
+
+	// filter is the incremental search over the loaded page; visible holds
+	// the indexes of items that match it.
+	filter   string
+	visible  []int
+	cursor   int
+	offset   int
+	selected map[int]bool
+
+	detailScroll int
+
+	focus pane
+	mode  mode
+
+	prompt    string
+	input     []rune
+	onSubmit  func(string)
+	onConfirm func()
+
+	status    string
+	statusErr bool
+
+	width, height int
+	quit          bool
+}
+
+// Run starts the UI and blocks until the user quits.
+func Run(ctx context.Context, opts Options) error {
+	t, err := openTerminal(os.Stdout)
+	if err != nil {
+		return err
+	}
+	defer t.close()
+
+	m := &model{
+		ctx:          ctx,
+		opts:         opts,
+		collectionID: opts.CollectionID,
+		search:       opts.Search,
+		selected:     make(map[int]bool),
+		focus:        paneList,
+	}
+	m.width, m.height = t.size()
+
+	m.status = "Loading..."
+	if err := t.draw(m.render()); err != nil {
+		return err
+	}
+
+	m.loadCollections()
+	m.loadRaindrops()
+
+	keys := make(chan key, 16)
+	go t.readKeys(keys)
+
+	ticker := time.NewTicker(resizePoll)
+	defer ticker.Stop()
+
+	for !m.quit {
+		if err := t.draw(m.render()); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if w, h := t.size(); w != m.width || h != m.height {
+				m.width, m.height = w, h
+			} else {
+				continue
+			}
+		case k, ok := <-keys:
+			if !ok {
+				return nil
+			}
+
+			m.handleKey(k)
+		}
+	}
+
+	return nil
+}
+
+func (m *model) call() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(m.ctx, requestTimeout)
+}
+
+func (m *model) setStatus(format string, args ...any) {
+	m.status, m.statusErr = fmt.Sprintf(format, args...), false
+}
+
+func (m *model) setError(err error) {
+	if m.opts.FormatError != nil {
+		err = m.opts.FormatError(err)
+	}
+
+	// Multi-line hints don't fit the status line.
+	msg, _, _ := strings.Cut(err.Error(), "\n")
+	m.status, m.statusErr = msg, true
+}
+
+func (m *model) loadCollections() {
+	ctx, cancel := m.call()
+	defer cancel()
+
+	m.collections = []collectionEntry{
+		{id: api.SystemCollectionAll, name: "All", label: "All"},
+		{id: api.SystemCollectionUnsorted, name: "Unsorted", label: "Unsorted"},
+		{id: api.SystemCollectionTrash, name: "Trash", label: "Trash"},
+	}
+
+	collections, err := m.opts.Client.Collections(ctx)
+	if err != nil {
+		m.setError(err)
+
+		return
+	}
+
+	for _, e := range output.NewCollectionTree(nil, collections).Entries() {
+		m.collections = append(m.collections, collectionEntry{
+			id:    e.Collection.ID,
+			name:  e.Collection.Title,
+			label: e.Prefix + e.Collection.Title,
+			count: e.Collection.Count,
+		})
+	}
+
+	for i, c := range m.collections {
+		if c.id == m.collectionID {
+			m.colCursor = i
+		}
+	}
+}
+
+func (m *model) loadRaindrops() {
+	ctx, cancel := m.call()
+	defer cancel()
+
+	resp, err := m.opts.Client.ListRaindrops(ctx, m.collectionID, api.ListOptions{
+		Search:  m.search,
+		Sort:    "-created",
+		Page:    m.page,
+		PerPage: api.MaxPerPage,
+	})
+	if err != nil {
+		m.setError(err)
+
+		return
+	}
+
+	m.items, m.total = resp.Items, resp.Count
+	m.cursor, m.offset, m.detailScroll = 0, 0, 0
+	m.applyFilter()
+	m.setStatus("%d raindrop(s)", m.total)
+}
+
+// applyFilter recomputes the visible items for the incremental search.
+func (m *model) applyFilter() {
+	m.visible = m.visible[:0]
+	needle := strings.ToLower(m.filter)
+
+	for i := range m.items {
+		if needle == "" || strings.Contains(searchText(&m.items[i]), needle) {
+			m.visible = append(m.visible, i)
+		}
+	}
+
+	m.cursor = clamp(m.cursor, 0, len(m.visible)-1)
+}
+
+// searchText is what the incremental search matches, lower-cased.
+func searchText(r *api.Raindrop) string {
+	return strings.ToLower(strings.Join([]string{r.Title, r.Link, r.Note, r.Excerpt, strings.Join(r.Tags, " ")}, "\n"))
+}
+
+// current returns the raindrop under the cursor, or nil.
+func (m *model) current() *api.Raindrop {
+	if len(m.visible) == 0 {
+		return nil
+	}
+
+	return &m.items[m.visible[m.cursor]]
+}
+
+// targets returns the visible selected raindrops, or the current one when
+// none is selected. Rows hidden by the filter are left alone.
+func (m *model) targets() []*api.Raindrop {
+	var out []*api.Raindrop
+
+	for _, i := range m.visible {
+		if m.selected[m.items[i].ID] {
+			out = append(out, &m.items[i])
+		}
+	}
+
+	if len(out) == 0 {
+		if r := m.current(); r != nil {
+			out = append(out, r)
+		}
+	}
+
+	return out
+}
+
+func (m *model) pages() int {
+	return max(1, (m.total+api.MaxPerPage-1)/api.MaxPerPage)
+}
+
+func (m *model) handleKey(k key) {
+	if k.code == keyCtrlC {
+		m.quit = true
+
+		return
+	}
+
+	switch m.mode {
+	case modeHelp:
+		m.mode = modeNormal
+	case modeFilter:
+		m.handleFilterKey(k)
+	case modePrompt:
+		m.handlePromptKey(k)
+	case modeConfirm:
+		m.mode = modeNormal
+		if k.code == keyRune && (k.r == 'y' || k.r == 'Y') {
+			m.onConfirm()
+		} else {
+			m.setStatus("Cancelled")
+		}
+	case modeNormal:
+		m.handleNormalKey(k)
+	}
+}
+
+func (m *model) handleFilterKey(k key) {
+	switch k.code {
+	case keyEnter:
+		m.mode = modeNormal
+	case keyEsc:
+		m.mode = modeNormal
+		m.filter = ""
+		m.applyFilter()
+	case keyBackspace:
+		if m.filter != "" {
+			r := []rune(m.filter)
+			m.filter = string(r[:len(r)-1])
+			m.applyFilter()
+		}
+	case keyCtrlU:
+		m.filter = ""
+		m.applyFilter()
+	case keyRune:
+		m.filter += string(k.r)
+		m.applyFilter()
+	}
+}
+
+func (m *model) handlePromptKey(k key) {
+	switch k.code {
+	case keyEnter:
+		m.mode = modeNormal
+		m.onSubmit(strings.TrimSpace(string(m.input)))
+	case keyEsc:
+		m.mode = modeNormal
+		m.setStatus("Cancelled")
+	case keyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case keyCtrlU:
+		m.input = m.input[:0]
+	case keyRune:
+		m.input = append(m.input, k.r)
+	}
+}
+
+// ask prompts for a line of input on the status line.
+func (m *model) ask(prompt, initial string, submit func(string)) {
+	m.mode, m.prompt, m.input, m.onSubmit = modePrompt, prompt, []rune(initial), submit
+}
+
+// confirm asks a yes/no question on the status line.
+func (m *model) confirm(prompt string, yes func()) {
+	m.mode, m.prompt, m.onConfirm = modeConfirm, prompt, yes
+}
+
+func (m *model) handleNormalKey(k key) {
+	switch k.code {
+	case keyUp:
+		m.move(-1)
+	case keyDown:
+		m.move(1)
+	case keyPageUp:
+		m.move(-m.listHeight())
+	case keyPageDown:
+		m.move(m.listHeight())
+	case keyHome:
+		m.move(-len(m.items) - len(m.collections))
+	case keyEnd:
+		m.move(len(m.items) + len(m.collections))
+	case keyTab, keyLeft, keyRight:
+		m.toggleFocus(k.code)
+	case keyEnter:
+		m.enter()
+	case keyEsc:
+		m.clearSelection()
+	case keyCtrlD:
+		m.detailScroll += m.detailHeight() / 2
+	case keyCtrlU:
+		m.detailScroll = max(0, m.detailScroll-m.detailHeight()/2)
+	case keyRune:
+		m.handleRune(k.r)
+	}
+}
+
+func (m *model) handleRune(r rune) {
+	switch r {
+	case 'q':
+		m.quit = true
+	case '?':
+		m.mode = modeHelp
+	case 'j':
+		m.move(1)
+	case 'k':
+		m.move(-1)
+	case 'g':
+		m.move(-len(m.items) - len(m.collections))
+	case 'G':
+		m.move(len(m.items) + len(m.collections))
+	case 'J':
+		m.detailScroll++
+	case 'K':
+		m.detailScroll = max(0, m.detailScroll-1)
+	case 'h', 'l':
+		m.toggleFocus(keyTab)
+	case 'n', ']':
+		m.changePage(1)
+	case 'p', '[':
+		m.changePage(-1)
+	case 'r':
+		m.loadCollections()
+		m.loadRaindrops()
+	case '/':
+		m.focus = paneList
+		m.mode = modeFilter
+	case 's':
+		m.ask("Search: ", m.search, func(q string) {
+			m.search, m.page, m.filter = q, 0, ""
+			m.clearSelection()
+			m.loadRaindrops()
+		})
+	case ' ':
+		m.toggleSelected()
+	case 'a':
+		m.selectAll()
+	case 'o':
+		m.openTargets()
+	case 'y':
+		m.copyTargets()
+	case 'f':
+		m.toggleFavorite()
+	case 't':
+		m.editTags()
+	case 'm':
+		m.moveTargets()
+	case 'd':
+		m.deleteTargets()
+	}
+}
+
+func (m *model) move(delta int) {
+	if m.focus == paneCollections {
+		m.colCursor = clamp(m.colCursor+delta, 0, len(m.collections)-1)
+
+		return
+	}
+
+	m.cursor = clamp(m.cursor+delta, 0, len(m.visible)-1)
+	m.detailScroll = 0
+}
+
+func (m *model) toggleFocus(code int) {
+	switch {
+	case code == keyLeft:
+		m.focus = paneCollections
+	case code == keyRight:
+		m.focus = paneList
+	case m.focus == paneList:
+		m.focus = paneCollections
+	default:
+		m.focus = paneList
+	}
+}
+
+func (m *model) enter() {
+	if m.focus == paneList {
+		m.toggleSelected()
+
+		return
+	}
+
+	if len(m.collections) == 0 {
+		return
+	}
+
+	m.collectionID = m.collections[m.colCursor].id
+	m.page, m.filter = 0, ""
+	m.clearSelection()
+	m.loadRaindrops()
+	m.focus = paneList
+}
+
+func (m *model) changePage(delta int) {
+	page := clamp(m.page+delta, 0, m.pages()-1)
+	if page == m.page {
+		return
+	}
+
+	// Actions apply to the loaded page, so the selection doesn't carry over.
+	m.page = page
+	m.clearSelection()
+	m.loadRaindrops()
+}
+
+func (m *model) toggleSelected() {
+	r := m.current()
+	if r == nil {
+		return
+	}
+
+	if m.selected[r.ID] {
+		delete(m.selected, r.ID)
+	} else {
+		m.selected[r.ID] = true
+	}
+
+	m.move(1)
+}
+
+// selectAll selects every visible raindrop, or clears the selection when
+// they are all selected already.
+func (m *model) selectAll() {
+	all := true
+
+	for _, i := range m.visible {
+		if !m.selected[m.items[i].ID] {
+			all = false
+		}
+	}
+
+	for _, i := range m.visible {
+		if all {
+			delete(m.selected, m.items[i].ID)
+		} else {
+			m.selected[m.items[i].ID] = true
+		}
+	}
+}
+
+// selectedVisible counts the selected raindrops the filter shows, which
+// are the ones actions apply to.
+func (m *model) selectedVisible() int {
+	n := 0
+
+	for _, i := range m.visible {
+		if m.selected[m.items[i].ID] {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (m *model) clearSelection() {
+	clear(m.selected)
+}
+
+func (m *model) openTargets() {
+	targets := m.targets()
+
+	for _, r := range targets {
+		if err := m.opts.Open(r.Link); err != nil {
+			m.setError(err)
+
+			return
+		}
+	}
+
+	m.setStatus("Opened %d link(s)", len(targets))
+}
+
+func (m *model) copyTargets() {
+	targets := m.targets()
+	if len(targets) == 0 {
+		return
+	}
+
+	links := make([]string, 0, len(targets))
+	for _, r := range targets {
+		links = append(links, r.Link)
+	}
+
+	if err := m.opts.Copy(strings.Join(links, "\n")); err != nil {
+		m.setError(err)
+
+		return
+	}
+
+	m.setStatus("Copied %d link(s)", len(targets))
+}
+
+// update applies req to each target, replacing the items with the updated
+// raindrops.
+func (m *model) update(targets []*api.Raindrop, req func(r *api.Raindrop) *api.UpdateRaindropRequest) int {
+	done := 0
+
+	for _, r := range targets {
+		ctx, cancel := m.call()
+		updated, err := m.opts.Client.UpdateRaindrop(ctx, r.ID, req(r))
+
+		cancel()
+
+		if err != nil {
+			m.setError(err)
+
+			return done
+		}
+
+		*r = *updated
+		done++
+	}
+
+	return done
+}
+
+func (m *model) toggleFavorite() {
+	targets := m.targets()
+	if len(targets) == 0 {
+		return
+	}
+
+	// Favorite all unless all are favorites already.
+	important := false
+
+	for _, r := range targets {
+		if !r.Important {
+			important = true
+		}
+	}
+
+	n := m.update(targets, func(*api.Raindrop) *api.UpdateRaindropRequest {
+		return &api.UpdateRaindropRequest{Important: &important}
+	})
+	if n == len(targets) {
+		m.setStatus("Updated %d raindrop(s)", n)
+	}
+}
+
+// editTags edits the tags of a single raindrop, or adds (tag) and removes
+// (-tag) tags on a selection.
+func (m *model) editTags() {
+	targets := m.targets()
+
+	switch len(targets) {
+	case 0:
+		return
+	case 1:
+		r := targets[0]
+		m.ask("Tags: ", strings.Join(r.Tags, ", "), func(s string) {
+			req := &api.UpdateRaindropRequest{}
+			req.SetTags(splitTags(s))
+
+			if m.update(targets, func(*api.Raindrop) *api.UpdateRaindropRequest {
+				return req
+			}) == 1 {
+				m.setStatus("Tags updated")
+			}
+		})
+	default:
+		m.ask(fmt.Sprintf("Tags for %d raindrops (tag adds, -tag removes): ", len(targets)), "", func(s string) {
+			var add, remove []string
+
+			for _, tag := range splitTags(s) {
+				if name, ok := strings.CutPrefix(tag, "-"); ok {
+					remove = append(remove, name)
+				} else {
+					add = append(add, tag)
+				}
+			}
+
+			n := m.update(targets, func(r *api.Raindrop) *api.UpdateRaindropRequest {
+				req := &api.UpdateRaindropRequest{}
+				req.SetTags(api.EditTags(r.Tags, add, remove))
+
+				return req
+			})
+			if n == len(targets) {
+				m.setStatus("Tags updated on %d raindrop(s)", n)
+			}
+		})
+	}
+}
+
+func (m *model) moveTargets() {
+	targets := m.targets()
+	if len(targets) == 0 {
+		return
+	}
+
+	m.ask(fmt.Sprintf("Move %d raindrop(s) to collection: ", len(targets)), "", func(name string) {
+		if name == "" {
+			return
+		}
+
+		ctx, cancel := m.call()
+		id, err := m.opts.Client.ResolveCollection(ctx, name)
+
+		cancel()
+
+		if err != nil {
+			m.setError(err)
+
+			return
+		}
+
+		n := m.update(targets, func(*api.Raindrop) *api.UpdateRaindropRequest {
+			req := &api.UpdateRaindropRequest{}
+			req.SetCollection(id)
+
+			return req
+		})
+		if n == len(targets) {
+			m.clearSelection()
+			m.loadRaindrops()
+			m.setStatus("Moved %d raindrop(s)", n)
+		}
+	})
+}
+
+func (m *model) deleteTargets() {
+	targets := m.targets()
+	if len(targets) == 0 {
+		return
+	}
+
+	m.confirm(fmt.Sprintf("Delete %d raindrop(s)? [y/N] ", len(targets)), func() {
+		for _, r := range targets {
+			ctx, cancel := m.call()
+			err := m.opts.Client.DeleteRaindrop(ctx, r.ID, false)
+
+			cancel()
+
+			if err != nil {
+				m.setError(err)
+				m.loadRaindrops()
+
+				return
+			}
+		}
+
+		m.clearSelection()
+		m.loadRaindrops()
+		m.setStatus("Deleted %d raindrop(s)", len(targets))
+	})
+}
+
+// splitTags parses a comma-separated tag list.
+func splitTags(s string) []string {
+	var tags []string
+
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+func clamp(v, lo, hi int) int {
+	if v > hi {
+		v = hi
+	}
+
+	if v < lo {
+		v = lo
+	}
+
+	return v
+}
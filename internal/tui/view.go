@@ -0,0 +1,311 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/dedene/raindrop-cli/internal/output"
+)
+
+// helpLines describe the key bindings.
+var helpLines = []string{
+	"Navigation",
+	"  j/k, ↑/↓        move          PgUp/PgDn, g/G  page up/down, top/bottom",
+	"  Tab, h/l, ←/→   switch pane   Enter           open collection / select",
+	"  n/p, ]/[        next/previous page",
+	"  J/K, ^D/^U      scroll details",
+	"",
+	"Search",
+	"  /               filter this page as you type (Enter keeps, Esc clears)",
+	"  s               Raindrop search in the collection",
+	"  r               reload",
+	"",
+	"Actions (on the selection, or the current raindrop)",
+	"  Space           select/unselect    a  select all/none    Esc  clear selection",
+	"  o               open in browser    y  copy URL(s)",
+	"  t               edit tags          m  move to collection",
+	"  f               toggle favorite    d  delete (to Trash)",
+	"",
+	"  q, ^C           quit               ?  this help",
+}
+
+const (
+	minPaneWidth    = 16
+	maxPaneWidth    = 32
+	narrowWidth     = 60
+	maxDomainWidth  = 22
+	listHeightRatio = 55 // percent of the body height
+)
+
+// layout returns the widths of the collections and list panes. Narrow
+// terminals show only the focused pane.
+func (m *model) layout() (int, int) {
+	if m.width < narrowWidth {
+		if m.focus == paneCollections {
+			return m.width, 0
+		}
+
+		return 0, m.width
+	}
+
+	left := clamp(m.width/4, minPaneWidth, maxPaneWidth)
+
+	return left, m.width - left - 1
+}
+
+func (m *model) bodyHeight() int {
+	return max(1, m.height-2)
+}
+
+func (m *model) listHeight() int {
+	return max(3, m.bodyHeight()*listHeightRatio/100)
+}
+
+func (m *model) detailHeight() int {
+	return max(0, m.bodyHeight()-m.listHeight()-1)
+}
+
+// render returns the screen lines.
+func (m *model) render() []string {
+	lines := make([]string, 0, m.height)
+	lines = append(lines, m.header())
+
+	body := m.body()
+	for i := 0; i < m.bodyHeight(); i++ {
+		if i < len(body) {
+			lines = append(lines, body[i])
+		} else {
+			lines = append(lines, "")
+		}
+	}
+
+	return append(lines, m.statusLine())
+}
+
+func (m *model) header() string {
+	parts := []string{"raindrop", m.collectionName(), fmt.Sprintf("page %d/%d", m.page+1, m.pages()), fmt.Sprintf("%d total", m.total)}
+
+	if m.search != "" {
+		parts = append(parts, "search: "+m.search)
+	}
+
+	if m.filter != "" {
+		parts = append(parts, fmt.Sprintf("filter: %s (%d)", m.filter, len(m.visible)))
+	}
+
+	if n := m.selectedVisible(); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d selected", n))
+	}
+
+	return output.StyleReverse(pad(" "+strings.Join(parts, " · "), m.width))
+}
+
+func (m *model) collectionName() string {
+	for _, c := range m.collections {
+		if c.id == m.collectionID {
+			return c.name
+		}
+	}
+
+	return fmt.Sprintf("collection %d", m.collectionID)
+}
+
+func (m *model) body() []string {
+	if m.mode == modeHelp {
+		lines := make([]string, 0, len(helpLines)+1)
+		lines = append(lines, "")
+
+		for _, l := range helpLines {
+			lines = append(lines, pad(" "+l, m.width))
+		}
+
+		return lines
+	}
+
+	left, right := m.layout()
+	colLines := m.collectionLines(left)
+	listLines := m.rightLines(right)
+
+	lines := make([]string, m.bodyHeight())
+	for i := range lines {
+		var b strings.Builder
+
+		if left > 0 {
+			b.WriteString(lineAt(colLines, i, left))
+		}
+
+		if left > 0 && right > 0 {
+			b.WriteString(output.StyleFaint("│"))
+		}
+
+		if right > 0 {
+			b.WriteString(lineAt(listLines, i, right))
+		}
+
+		lines[i] = b.String()
+	}
+
+	return lines
+}
+
+func (m *model) collectionLines(width int) []string {
+	if width == 0 {
+		return nil
+	}
+
+	height := m.bodyHeight()
+	m.colOffset = scrollOffset(m.colCursor, m.colOffset, height, len(m.collections))
+
+	lines := make([]string, 0, height)
+
+	for i := m.colOffset; i < len(m.collections) && len(lines) < height; i++ {
+		c := m.collections[i]
+
+		label := c.label
+		if c.count > 0 {
+			label += fmt.Sprintf(" (%d)", c.count)
+		}
+
+		switch {
+		case i == m.colCursor && m.focus == paneCollections:
+			lines = append(lines, output.StyleReverse(pad(" "+label, width)))
+		case c.id == m.collectionID:
+			lines = append(lines, output.StyleBold(pad(" "+label, width)))
+		default:
+			lines = append(lines, pad(" "+label, width))
+		}
+	}
+
+	return lines
+}
+
+// rightLines returns the raindrop list followed by the details pane.
+func (m *model) rightLines(width int) []string {
+	if width == 0 {
+		return nil
+	}
+
+	listHeight := m.listHeight()
+	m.offset = scrollOffset(m.cursor, m.offset, listHeight, len(m.visible))
+
+	lines := make([]string, 0, m.bodyHeight())
+
+	if len(m.visible) == 0 {
+		lines = append(lines, output.StyleFaint(" No raindrops"))
+	}
+
+	for i := m.offset; i < len(m.visible) && len(lines) < listHeight; i++ {
+		lines = append(lines, m.raindropLine(i, width))
+	}
+
+	for len(lines) < listHeight {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, output.StyleFaint(pad("── Details ", width)))
+
+	return append(lines, m.detailLines(width)...)
+}
+
+func (m *model) raindropLine(i, width int) string {
+	r := &m.items[m.visible[i]]
+
+	sel, fav := " ", " "
+	if m.selected[r.ID] {
+		sel = "●"
+	}
+
+	if r.Important {
+		fav = "★"
+	}
+
+	domainWidth := min(maxDomainWidth, width/4)
+	titleWidth := max(1, width-5-domainWidth)
+	title := r.Title
+
+	if title == "" {
+		title = r.Link
+	}
+
+	if i == m.cursor && m.focus == paneList {
+		return output.StyleReverse(pad(fmt.Sprintf(" %s%s %s %s", sel, fav, pad(title, titleWidth), r.Domain), width))
+	}
+
+	return pad(fmt.Sprintf(" %s%s %s %s", output.StyleGreen(sel), output.StyleYellow(fav), pad(title, titleWidth), output.StyleFaint(r.Domain)), width)
+}
+
+func (m *model) detailLines(width int) []string {
+	r := m.current()
+	if r == nil {
+		return nil
+	}
+
+	var buf bytes.Buffer
+
+	output.FormatRaindropDetail(&buf, r)
+
+	var lines []string
+
+	for _, l := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		for _, w := range output.WrapWidth(l, width-1) {
+			lines = append(lines, " "+w)
+		}
+	}
+
+	m.detailScroll = clamp(m.detailScroll, 0, max(0, len(lines)-m.detailHeight()))
+
+	return lines[m.detailScroll:]
+}
+
+func (m *model) statusLine() string {
+	switch m.mode {
+	case modeFilter:
+		return pad("/"+m.filter+"█", m.width)
+	case modePrompt:
+		return pad(m.prompt+string(m.input)+"█", m.width)
+	case modeConfirm:
+		return pad(output.StyleYellow(m.prompt), m.width)
+	case modeHelp:
+		return pad(output.StyleFaint("Press any key to close help"), m.width)
+	case modeNormal:
+	}
+
+	hint := "? help  q quit"
+	status := m.status
+
+	if m.statusErr {
+		status = output.StyleRed(status)
+	}
+
+	return pad(" "+status, max(0, m.width-len(hint)-1)) + output.StyleFaint(hint)
+}
+
+// scrollOffset keeps cursor within the window of height rows at offset.
+func scrollOffset(cursor, offset, height, n int) int {
+	if cursor < offset {
+		offset = cursor
+	}
+
+	if cursor >= offset+height {
+		offset = cursor - height + 1
+	}
+
+	return clamp(offset, 0, max(0, n-height))
+}
+
+// lineAt returns lines[i] padded to width, or blanks past the end.
+func lineAt(lines []string, i, width int) string {
+	if i < len(lines) {
+		return pad(lines[i], width)
+	}
+
+	return strings.Repeat(" ", width)
+}
+
+// pad truncates or space-pads s to exactly width cells.
+func pad(s string, width int) string {
+	s = output.TruncateWidth(s, width)
+
+	return s + strings.Repeat(" ", max(0, width-output.VisibleWidth(s)))
+}